@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"mock-jack/internal/game"
+)
+
+const (
+	shoeDecks  = 6
+	defaultBet = 10
+)
+
+// A headless blackjack table read from stdin, one command per line:
+// d deals a round or doubles down during the player's turn, h hits, s stands
+// and q quits.
+func main() {
+	g := game.NewGame(shoeDecks)
+	in := bufio.NewScanner(os.Stdin)
+
+	printTable(g)
+	for in.Scan() {
+		switch strings.ToLower(strings.TrimSpace(in.Text())) {
+			case "h":
+				g.PlayerHit()
+			case "s":
+				g.PlayerStand()
+			case "d":
+				if g.State == game.PlayerTurn {
+					g.PlayerDoubleDown()
+				} else {
+					deal(g)
+				}
+			case "q":
+				return
+			default:
+				fmt.Println("commands: d(eal/double), h(it), s(tand), q(uit)")
+				continue
+		}
+		printTable(g)
+	}
+}
+
+func deal(g *game.Game) {
+	if err := g.PlaceBet(defaultBet); err != nil {
+		fmt.Println(err)
+		return
+	}
+	g.Deal()
+}
+
+func printTable(g *game.Game) {
+	fmt.Printf("%s   Bankroll: %d   Bet: %d\n", g.State, g.Bankroll, g.Bet)
+	if g.State == game.WaitingDeal {
+		fmt.Println("d to deal")
+		return
+	}
+	if up, _ := g.DealerUpcard(); g.HoleCardHidden() {
+		fmt.Printf("Dealer: %s ?? (%s)\n", up, g.DealerValueString())
+	} else {
+		fmt.Printf("Dealer: %s (%s)\n", g.Dealer.String(), g.DealerValueString())
+	}
+	for i, hand := range g.PlayerHands {
+		marker := " "
+		if g.State == game.PlayerTurn && i == g.Active {
+			marker = ">"
+		}
+		fmt.Printf("%s Player: %s (%s)\n", marker, hand.String(), hand.ValueString())
+	}
+	if g.Result != "" {
+		fmt.Println(g.Result)
+	}
+}
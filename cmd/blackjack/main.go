@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"mock-jack/internal/app"
 
@@ -8,11 +9,17 @@ import (
 )
 
 func main () {
+	countQuiz := flag.Int("count-quiz", 0, "ask for the Hi-Lo running count every `n` rounds; 0 never asks")
+	flag.Parse()
+
 	// Window basic settings
 	ebiten.SetWindowSize(960, 540)
 	ebiten.SetWindowTitle("MockJack")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	if err := ebiten.RunGame(app.New()); err != nil {
+	a := app.New()
+	a.CountQuizEvery = *countQuiz
+	if err := ebiten.RunGame(a); err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"mock-jack/internal/game"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// dealTicks is how long a card takes to slide from the shoe to its slot,
+	// and dealStagger the delay between consecutive cards, both in ticks.
+	dealTicks   = 15
+	dealStagger = 6
+)
+
+// cardAnim is a card sliding from the shoe to its slot in a hand. hand is
+// the index of a player hand and always 0 for the dealer's. tick is negative
+// while the card waits for the ones dealt before it.
+type cardAnim struct {
+	card game.Card
+	to   string
+	hand int
+	slot int
+	tick int
+}
+
+// onCardDealt is the game's OnCardDealt hook. The card has already been
+// added to its hand, so its slot is the last one, except while splitting:
+// the second card of each split hand goes in its second slot, and the new
+// hand is only added to PlayerHands after its card is dealt.
+func (a *App) onCardDealt(to string, c game.Card) {
+	hand, slot := 0, len(a.game.Dealer.Cards)-1
+	switch {
+		case to != "player":
+			a.sounds.play(soundDeal)
+		case a.splitting:
+			hand, slot = a.game.Active+a.splitDealt, 1
+			a.splitDealt++
+			a.sounds.play(soundDeal)
+		default:
+			active := &a.game.PlayerHands[a.game.Active]
+			hand, slot = a.game.Active, len(active.Cards)-1
+			switch {
+				case active.IsBust():
+					a.sounds.play(soundBust)
+				case slot >= 2:
+					a.sounds.play(soundHit)
+				default:
+					a.sounds.play(soundDeal)
+			}
+	}
+	a.anims = append(a.anims, &cardAnim{
+		card: c,
+		to:   to,
+		hand: hand,
+		slot: slot,
+		tick: -len(a.anims) * dealStagger,
+	})
+}
+
+func (a *App) updateAnims() {
+	live := a.anims[:0]
+	for _, anim := range a.anims {
+		anim.tick++
+		if anim.tick < dealTicks {
+			live = append(live, anim)
+		}
+	}
+	a.anims = live
+}
+
+func (a *App) animating() bool { return len(a.anims) > 0 }
+
+// inFlight reports whether the card in slot of the given hand is still
+// sliding into place, so it isn't drawn at its final position yet.
+func (a *App) inFlight(to string, hand, slot int) bool {
+	for _, anim := range a.anims {
+		if anim.to == to && anim.hand == hand && anim.slot == slot {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) drawAnims(screen *ebiten.Image) {
+	for _, anim := range a.anims {
+		if anim.tick < 0 {
+			continue
+		}
+		toX, toY := slotX(anim.slot), dealerY
+		if anim.to == "player" {
+			toX, toY = a.playerX(anim.hand, anim.slot), a.playerY()
+		}
+		// Ease out so cards decelerate as they land.
+		t := float64(anim.tick) / dealTicks
+		t = 1 - (1-t)*(1-t)
+		shoeX := a.shoeX()
+		x := shoeX + int(t*float64(toX-shoeX))
+		y := dealerY + int(t*float64(toY-dealerY))
+
+		if anim.to == "dealer" && anim.slot == 1 && a.game.HoleCardHidden() {
+			a.drawCardBack(screen, x, y)
+		} else {
+			a.drawCard(screen, anim.card, x, y)
+		}
+	}
+}
+
+func slotX(slot int) int {
+	return tableMargin + slot*(cardWidth+cardGap)
+}
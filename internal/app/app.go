@@ -1,18 +1,256 @@
 package app
 
+import (
+	"log"
+	"time"
+
+	"mock-jack/internal/game"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
 const (
+	// screenWidth and screenHeight are the initial window size, and the
+	// fixed logical size when KeepAspect is set. The table is never laid out
+	// smaller than minWidth by minHeight.
 	screenWidth  = 960
 	screenHeight = 540
+	minWidth     = 640
+	minHeight    = 360
+
+	shoeDecks  = 6
+	defaultBet = 10
+
+	// shuffleNotice is how long "Shuffling" shows by the shoe after a
+	// reshuffle.
+	shuffleNotice = 1500 * time.Millisecond
 )
 
 type App struct {
+	game *game.Game
+	// KeepAspect keeps the 960x540 layout and lets ebiten scale it uniformly
+	// to the window instead of laying the table out for the window's size.
+	KeepAspect bool
+	// Paused freezes input and animations, keeping the round as it is. P
+	// toggles it, and losing window focus pauses.
+	Paused bool
+	// Debug overlays the frame rate and the game's state, shoe and running
+	// count. F3 toggles it.
+	Debug bool
+	// CountQuizEvery, if positive, trains card counting: every that many
+	// rounds the table waits for the player to type in the Hi-Lo running
+	// count, and the share answered right is kept on screen. The
+	// -count-quiz flag of cmd/blackjack sets it.
+	CountQuizEvery int
+	// AutoDealDelay, if positive, deals the next round by itself this long
+	// after a round ends.
+	AutoDealDelay time.Duration
+	// roundOver is when the last round ended, and shuffled when the shoe
+	// was last reshuffled.
+	roundOver time.Time
+	shuffled  time.Time
+	// width and height are the current logical screen size.
+	width, height int
+	// label is scratch space for tinted text.
+	label *ebiten.Image
+	theme Theme
+	// themeIndex is the built-in theme last chosen with T.
+	themeIndex int
+	// back is the drawn card back of the theme, redrawn in place on every
+	// switch.
+	back    *ebiten.Image
+	buttons []*button
+	anims   []*cardAnim
+	sounds  *sounds
+	// splitting is set while PlayerSplit deals, and splitDealt counts the
+	// cards it has dealt so far, so onCardDealt can tell which hand each
+	// goes to.
+	splitting  bool
+	splitDealt int
+	// hint shows the basic-strategy play for the active hand until the
+	// player's turn ends.
+	hint bool
+	// quiz is the open count quiz, if any, roundsSinceQuiz the rounds played
+	// since the last one, and quizResult how the last answer was graded.
+	quiz            *countQuiz
+	roundsSinceQuiz int
+	quizResult      string
+	score           quizScore
 }
 
 func New() *App {
-	a := &App{}
+	a := &App{
+		game:   game.NewGame(shoeDecks),
+		label:  ebiten.NewImage(cardWidth, glyphHeight),
+		width:  screenWidth,
+		height: screenHeight,
+	}
+	a.SetTheme(Themes[0])
+	a.buttons = a.newButtons()
+	a.layoutButtons()
+	a.sounds = newSounds()
+	a.game.OnCardDealt = a.onCardDealt
+	a.game.OnStateChange = a.onStateChange
+	a.game.Deck.OnShuffle = func() { a.shuffled = time.Now() }
+	if err := loadCardFaces(); err != nil {
+		log.Printf("card sprites unavailable, drawing text cards: %v", err)
+	}
+	if err := loadFont(); err != nil {
+		log.Printf("TTF font unavailable, printing in the debug font: %v", err)
+	}
 	return a
 }
 
+// Update handles keyboard and mouse input: H hits, S stands, X splits and D
+// deals a new round or doubles down during the player's turn, always acting
+// on the active hand, and ? shows the basic-strategy hint for it. The
+// on-screen buttons do the same when clicked. Keys and clicks fire once per
+// press, and actions that aren't valid in the current state are ignored by
+// the game. Input is ignored while dealt cards are still sliding into place.
+// While a count quiz is open the keyboard only answers it. M toggles sound,
+// T switches to the next theme, F3 toggles Debug and P pauses; the frame
+// that unpauses does nothing else.
+func (a *App) Update() error {
+	g := a.game
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		a.Paused = !a.Paused
+		return nil
+	}
+	if !ebiten.IsFocused() {
+		a.Paused = true
+	}
+	if a.Paused {
+		return nil
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		a.sounds.Muted = !a.sounds.Muted
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		a.Debug = !a.Debug
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		a.nextTheme()
+	}
+	if a.animating() {
+		a.updateAnims()
+		return nil
+	}
+	if a.quiz != nil {
+		a.updateQuiz()
+		return nil
+	}
+	if a.AutoDealDelay > 0 && g.State == game.RoundOver && time.Since(a.roundOver) >= a.AutoDealDelay {
+		a.deal()
+		return nil
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		a.click(ebiten.CursorPosition())
+		return nil
+	}
+	switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyH):
+			g.PlayerHit()
+		case inpututil.IsKeyJustPressed(ebiten.KeyS):
+			g.PlayerStand()
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			a.split()
+		case inpututil.IsKeyJustPressed(ebiten.KeySlash):
+			a.hint = a.playerTurn()
+		case inpututil.IsKeyJustPressed(ebiten.KeyD):
+			if g.State == game.PlayerTurn {
+				g.PlayerDoubleDown()
+			} else {
+				a.deal()
+			}
+	}
+	return nil
+}
+
+func (a *App) deal() {
+	g := a.game
+	if !a.canDeal() {
+		return
+	}
+	if err := g.PlaceBet(defaultBet); err != nil {
+		return
+	}
+	g.Deal()
+}
+
+func (a *App) onStateChange(old, new game.State) {
+	if new != game.PlayerTurn {
+		a.hint = false
+	}
+	if new == game.RoundOver {
+		a.roundOver = time.Now()
+		a.roundEnded()
+		if a.game.Outcome.Net > 0 {
+			a.sounds.play(soundWin)
+		}
+	}
+}
+
+func (a *App) canDeal() bool {
+	return a.game.State == game.WaitingDeal || a.game.State == game.RoundOver
+}
+
+func (a *App) playerTurn() bool {
+	return a.game.State == game.PlayerTurn
+}
+
+func (a *App) canDouble() bool {
+	return a.game.CanDoubleDown()
+}
+
+// split splits the active hand, telling onCardDealt where its cards go.
+func (a *App) split() {
+	a.splitting, a.splitDealt = true, 0
+	a.game.PlayerSplit()
+	a.splitting = false
+}
+
+func (a *App) canSplit() bool {
+	return a.game.CanSplit()
+}
+
 func (a *App) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return screenWidth, screenHeight
-}
\ No newline at end of file
+	width, height := screenWidth, screenHeight
+	if !a.KeepAspect {
+		width = max(outsideWidth, minWidth)
+		height = max(outsideHeight, minHeight)
+	}
+	if width != a.width || height != a.height {
+		a.width, a.height = width, height
+		a.layoutButtons()
+	}
+	return width, height
+}
+
+// playerY is the top of the player's cards, a little below the middle of the
+// table.
+func (a *App) playerY() int { return a.height * 5 / 9 }
+
+// handLayout returns the width of the column each player hand is laid out
+// in and the spacing of the cards within it. A single hand spaces its cards
+// like the dealer's; split hands share the table width, their cards drawn
+// closer together so five fit in a column.
+func (a *App) handLayout() (column, step int) {
+	column = a.width - 2*tableMargin
+	n := len(a.game.PlayerHands)
+	if n <= 1 {
+		return column, cardWidth + cardGap
+	}
+	column /= n
+	step = min(cardWidth+cardGap, (column-cardWidth-cardGap)/4)
+	return column, max(step, glyphWidth*3)
+}
+
+// playerX is the left edge of the card in slot of player hand h.
+func (a *App) playerX(h, slot int) int {
+	column, step := a.handLayout()
+	return tableMargin + h*column + slot*step
+}
+
+// shoeX is the left edge of the shoe, in the dealer's row at the right.
+func (a *App) shoeX() int { return a.width - tableMargin - cardWidth }
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"mock-jack/internal/game"
+)
+
+func TestFourSplitHandsFitTheWindow(t *testing.T) {
+	for _, width := range []int{minWidth, screenWidth} {
+		a := &App{game: game.NewGame(1), width: width, height: screenHeight}
+		a.game.PlayerHands = make([]game.Hand, 4)
+		// Each hand's fifth card must end before the next hand starts, and
+		// the last hand inside the margin.
+		for h := 0; h < 4; h++ {
+			end := a.playerX(h, 4) + cardWidth
+			limit := width - tableMargin
+			if h < 3 {
+				limit = a.playerX(h+1, 0)
+			}
+			if end > limit {
+				t.Errorf("width %d: hand %d ends at %d, past %d", width, h, end, limit)
+			}
+		}
+	}
+}
+
+func TestWinSoundPlays(t *testing.T) {
+	// Player 10,9 against the dealer's 10,7.
+	g := game.NewGame(1)
+	g.Deck = game.NewStackedDeck([]game.Card{{Rank: game.Ten}, {Rank: game.Ten}, {Rank: game.Nine}, {Rank: game.Seven}})
+	var played []sound
+	a := &App{game: g, sounds: &sounds{played: func(s sound) { played = append(played, s) }}}
+	g.OnStateChange = a.onStateChange
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerStand()
+	if !slices.Contains(played, soundWin) {
+		t.Errorf("sounds played for a won round = %v, want the win sound", played)
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"embed"
+	"image"
+	_ "image/png"
+
+	"mock-jack/internal/game"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed assets
+var assets embed.FS
+
+// cardFaces maps each card to its sprite on the sheet, which has one row per
+// suit and one column per rank. It stays empty if the sheet fails to load, in
+// which case cards are drawn as text.
+var cardFaces map[game.Card]*ebiten.Image
+
+func loadCardFaces() error {
+	data, err := assets.ReadFile("assets/cards.png")
+	if err != nil {
+		return err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	sheet := ebiten.NewImageFromImage(img)
+
+	cardFaces = make(map[game.Card]*ebiten.Image)
+	for s := game.Clubs; s <= game.Spades; s++ {
+		for r := game.Ace; r <= game.King; r++ {
+			x := (int(r) - 1) * cardWidth
+			y := int(s) * cardHeight
+			rect := image.Rect(x, y, x+cardWidth, y+cardHeight)
+			cardFaces[game.Card{Suit: s, Rank: r}] = sheet.SubImage(rect).(*ebiten.Image)
+		}
+	}
+	return nil
+}
+
+// cardImage returns the sprite for c, or nil if the sheet isn't loaded.
+func cardImage(c game.Card) *ebiten.Image {
+	return cardFaces[c]
+}
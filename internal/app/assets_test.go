@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+
+	"mock-jack/internal/game"
+)
+
+func TestCardImageEveryCard(t *testing.T) {
+	if err := loadCardFaces(); err != nil {
+		t.Fatalf("loadCardFaces: %v", err)
+	}
+	for s := game.Clubs; s <= game.Spades; s++ {
+		for r := game.Ace; r <= game.King; r++ {
+			c := game.Card{Suit: s, Rank: r}
+			img := cardImage(c)
+			if img == nil {
+				t.Errorf("cardImage(%v) = nil", c)
+				continue
+			}
+			if size := img.Bounds().Size(); size.X != cardWidth || size.Y != cardHeight {
+				t.Errorf("cardImage(%v) is %v, want %dx%d", c, size, cardWidth, cardHeight)
+			}
+		}
+	}
+}
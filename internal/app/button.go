@@ -0,0 +1,82 @@
+package app
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	buttonWidth  = 80
+	buttonHeight = 32
+	buttonGap    = 10
+)
+
+var (
+	buttonColor   = color.RGBA{0xe0, 0xb0, 0x30, 0xff}
+	disabledColor = color.RGBA{0x70, 0x70, 0x70, 0xff}
+)
+
+// button is a labelled rectangle that runs action when clicked while enabled.
+type button struct {
+	label      string
+	x, y, w, h int
+	action     func()
+	enabled    func() bool
+}
+
+func (b *button) contains(x, y int) bool {
+	return x >= b.x && x < b.x+b.w && y >= b.y && y < b.y+b.h
+}
+
+func (a *App) newButtons() []*button {
+	g := a.game
+	labels := []string{"Deal", "Hit", "Stand", "Double", "Split"}
+	actions := []func(){a.deal, g.PlayerHit, g.PlayerStand, g.PlayerDoubleDown, a.split}
+	enabled := []func() bool{a.canDeal, a.playerTurn, a.playerTurn, a.canDouble, a.canSplit}
+
+	buttons := make([]*button, len(labels))
+	for i := range labels {
+		buttons[i] = &button{
+			label:   labels[i],
+			w:       buttonWidth,
+			h:       buttonHeight,
+			action:  actions[i],
+			enabled: enabled[i],
+		}
+	}
+	return buttons
+}
+
+// layoutButtons lines the buttons up in the bottom right corner of the
+// current screen size.
+func (a *App) layoutButtons() {
+	x := a.width - tableMargin - len(a.buttons)*(buttonWidth+buttonGap) + buttonGap
+	for i, b := range a.buttons {
+		b.x = x + i*(buttonWidth+buttonGap)
+		b.y = a.height - tableMargin - buttonHeight
+	}
+}
+
+// click fires the enabled button under the cursor, once per mouse press.
+func (a *App) click(x, y int) {
+	for _, b := range a.buttons {
+		if b.contains(x, y) && b.enabled() {
+			b.action()
+			return
+		}
+	}
+}
+
+func (a *App) drawButtons(screen *ebiten.Image) {
+	for _, b := range a.buttons {
+		fill := buttonColor
+		if !b.enabled() {
+			fill = disabledColor
+		}
+		vector.FillRect(screen, float32(b.x), float32(b.y), float32(b.w), float32(b.h), fill, false)
+		vector.StrokeRect(screen, float32(b.x), float32(b.y), float32(b.w), float32(b.h), 1, edgeColor, false)
+		a.drawLabel(screen, b.label, b.x+8, b.y+(b.h-glyphHeight)/2, edgeColor)
+	}
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"mock-jack/internal/game"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	cardWidth   = 60
+	cardHeight  = 84
+	cardGap     = 14
+	tableMargin = 40
+
+	dealerY = 60
+
+	// Size of the debug font glyphs, used for text when the TTF doesn't
+	// load.
+	glyphWidth  = 6
+	glyphHeight = 16
+)
+
+var (
+	faceColor = color.RGBA{0xf8, 0xf4, 0xe8, 0xff}
+	edgeColor = color.Black
+	redSuit   = color.RGBA{0xc0, 0x10, 0x10, 0xff}
+	// shadeColor dims the table behind the pause banner.
+	shadeColor = color.RGBA{0x00, 0x00, 0x00, 0xa0}
+)
+
+func (a *App) Draw(screen *ebiten.Image) {
+	g := a.game
+	screen.Fill(a.theme.FeltColor)
+
+	a.drawCardBack(screen, a.shoeX(), dealerY)
+	if time.Since(a.shuffled) < shuffleNotice {
+		// Lined up with the shoe's right edge, however wide the text grows.
+		x := a.shoeX() + cardWidth - a.textWidth("Shuffling", textSize)
+		a.drawText(screen, "Shuffling", x, dealerY+cardHeight+4, textSize)
+	}
+
+	a.drawText(screen, "Dealer", tableMargin, dealerY-a.lineHeight(), textSize)
+	for i, c := range g.Dealer.Cards {
+		switch {
+			case a.inFlight("dealer", 0, i):
+				// Drawn by drawAnims until it lands.
+			case i == 1 && g.HoleCardHidden():
+				a.drawCardBack(screen, slotX(i), dealerY)
+			default:
+				a.drawCard(screen, c, slotX(i), dealerY)
+		}
+	}
+
+	playerY := a.playerY()
+	a.drawText(screen, "Player", tableMargin, playerY-a.lineHeight(), textSize)
+	for h, hand := range g.PlayerHands {
+		for i, c := range hand.Cards {
+			if !a.inFlight("player", h, i) {
+				a.drawCard(screen, c, a.playerX(h, i), playerY)
+			}
+		}
+		if h == g.Active && g.State == game.PlayerTurn && len(g.PlayerHands) > 1 {
+			a.outlineHand(screen, h, len(hand.Cards))
+		}
+	}
+	a.drawAnims(screen)
+	if a.hint && g.State == game.PlayerTurn {
+		a.drawHint(screen)
+	}
+
+	line := a.lineHeight()
+	status := fmt.Sprintf("%s   Bankroll: %d   Bet: %d", g.State, g.Bankroll, g.Bet)
+	a.drawText(screen, status, tableMargin, a.height-4*line, textSize)
+	a.drawText(screen, turnPrompt(g.State), tableMargin, a.height-3*line, textSize)
+	if g.Result != "" {
+		a.drawText(screen, g.Result, tableMargin, a.height-2*line, textSize)
+	}
+	a.drawButtons(screen)
+	if a.Paused {
+		a.drawPaused(screen)
+	}
+	if a.CountQuizEvery > 0 {
+		a.drawTrainer(screen)
+	}
+	if a.Debug {
+		a.drawDebug(screen)
+	}
+}
+
+// drawDebug prints the frame rate and game internals along the top edge.
+func (a *App) drawDebug(screen *ebiten.Image) {
+	g := a.game
+	count := fmt.Sprintf("%+d", g.Deck.RunningCount())
+	if a.quiz != nil {
+		count = "?"
+	}
+	line := fmt.Sprintf("FPS %.1f   %s   Deck %d/%d   Count %s",
+		ebiten.ActualFPS(), g.State, g.Deck.Remaining(), g.Deck.Size(), count)
+	a.drawText(screen, line, tableMargin, 4, textSize)
+}
+
+func (a *App) drawPaused(screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, float32(a.width), float32(a.height), shadeColor, false)
+	const banner = "PAUSED - press P to resume"
+	x := (a.width - a.textWidth(banner, textSize)) / 2
+	a.drawText(screen, banner, x, (a.height-a.lineHeight())/2, textSize)
+}
+
+// drawHint shows the basic-strategy play for the active hand beside the
+// player label. It is worked out every frame, so it follows the hand.
+func (a *App) drawHint(screen *ebiten.Image) {
+	g := a.game
+	up, ok := g.DealerUpcard()
+	hand := g.ActiveHand()
+	if !ok || hand == nil {
+		return
+	}
+	hint := "Hint: " + game.BasicStrategy(*hand, up, g.Rules).String()
+	x := tableMargin + a.textWidth("Player", textSize) + a.lineHeight()
+	y := a.playerY() - a.lineHeight() - 2
+	vector.FillRect(screen, float32(x-4), float32(y), float32(a.textWidth(hint, textSize)+8), float32(a.lineHeight()), shadeColor, false)
+	a.drawText(screen, hint, x, y+2, textSize)
+}
+
+// outlineHand frames the first cards of player hand h to mark it as the one
+// being played.
+func (a *App) outlineHand(screen *ebiten.Image, h, cards int) {
+	_, step := a.handLayout()
+	x := a.playerX(h, 0) - 3
+	w := cardWidth + max(cards-1, 0)*step + 6
+	vector.StrokeRect(screen, float32(x), float32(a.playerY()-3), float32(w), cardHeight+6, 2, buttonColor, false)
+}
+
+func turnPrompt(s game.State) string {
+	switch s {
+		case game.PlayerTurn:
+			return "Your turn: H hit, S stand, D double, X split"
+		case game.DealerTurn:
+			return "Dealer's turn"
+		default:
+			return "Press D to deal"
+	}
+}
+
+func (a *App) drawCard(screen *ebiten.Image, c game.Card, x, y int) {
+	if img := cardImage(c); img != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x), float64(y))
+		screen.DrawImage(img, op)
+		return
+	}
+
+	vector.FillRect(screen, float32(x), float32(y), cardWidth, cardHeight, faceColor, false)
+	vector.StrokeRect(screen, float32(x), float32(y), cardWidth, cardHeight, 1, edgeColor, false)
+
+	var ink color.Color = edgeColor
+	if c.Suit == game.Hearts || c.Suit == game.Diamonds {
+		ink = redSuit
+	}
+	// The debug font, the fallback for the TTF, is ASCII-only, so cards are
+	// labelled with their code rather than the suit symbols from Card.String.
+	a.drawLabel(screen, c.Code(), x+6, y+4, ink)
+}
+
+// drawLabel prints s in clr at labelSize, which doesn't scale with the
+// window. The debug font only draws white, so without the TTF the text is
+// rendered to a scratch image first and tinted when copied to the screen.
+func (a *App) drawLabel(screen *ebiten.Image, s string, x, y int, clr color.Color) {
+	if face := fontFace(labelSize); face != nil {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x), float64(y))
+		op.ColorScale.ScaleWithColor(clr)
+		text.Draw(screen, s, face, op)
+		return
+	}
+	a.label.Clear()
+	ebitenutil.DebugPrintAt(a.label, s, 0, 0)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(clr)
+	screen.DrawImage(a.label, op)
+}
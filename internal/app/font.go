@@ -0,0 +1,91 @@
+package app
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	// textSize is the pixel size of table text at the initial window
+	// height, and lineSpacing the distance between its lines; both grow
+	// with the window. Card and button labels stay at labelSize, as the
+	// cards and buttons don't grow.
+	textSize    = 14
+	lineSpacing = 20
+	labelSize   = 13
+)
+
+// tableFont is the embedded Go Regular TTF. It stays nil if the font fails
+// to load, in which case text is printed in the debug font.
+var tableFont *text.GoTextFaceSource
+
+// faces holds a face of tableFont for each pixel size drawn so far.
+var faces = map[int]*text.GoTextFace{}
+
+func loadFont() error {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		return err
+	}
+	tableFont = src
+	return nil
+}
+
+// fontFace returns tableFont at size pixels, or nil without the font.
+func fontFace(size int) *text.GoTextFace {
+	if tableFont == nil {
+		return nil
+	}
+	if face, ok := faces[size]; ok {
+		return face
+	}
+	face := &text.GoTextFace{Source: tableFont, Size: float64(size)}
+	faces[size] = face
+	return face
+}
+
+// printAt prints s in white at size pixels with its top left corner at x, y.
+func printAt(screen *ebiten.Image, s string, x, y, size int) {
+	face := fontFace(size)
+	if face == nil {
+		ebitenutil.DebugPrintAt(screen, s, x, y)
+		return
+	}
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	text.Draw(screen, s, face, op)
+}
+
+// measure returns the width printAt draws s at size pixels.
+func measure(s string, size int) int {
+	face := fontFace(size)
+	if face == nil {
+		return len(s) * glyphWidth
+	}
+	return int(math.Ceil(text.Advance(s, face)))
+}
+
+// scaled returns size, given for the initial window height, at the current
+// one. Text never shrinks below it in a smaller window.
+func (a *App) scaled(size int) int {
+	return size * max(a.height, screenHeight) / screenHeight
+}
+
+// drawText prints table text: s in white at size pixels, scaled to the
+// window, with its top left corner at x, y.
+func (a *App) drawText(screen *ebiten.Image, s string, x, y, size int) {
+	printAt(screen, s, x, y, a.scaled(size))
+}
+
+// textWidth returns the width drawText draws s at size.
+func (a *App) textWidth(s string, size int) int {
+	return measure(s, a.scaled(size))
+}
+
+// lineHeight is the distance between lines of table text.
+func (a *App) lineHeight() int { return a.scaled(lineSpacing) }
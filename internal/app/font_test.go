@@ -0,0 +1,29 @@
+package app
+
+import "testing"
+
+func TestTextScalesWithWindow(t *testing.T) {
+	for _, tt := range []struct{ height, want int }{
+		{minHeight, textSize},
+		{screenHeight, textSize},
+		{2 * screenHeight, 2 * textSize},
+	} {
+		a := &App{height: tt.height}
+		if got := a.scaled(textSize); got != tt.want {
+			t.Errorf("height %d: scaled(%d) = %d, want %d", tt.height, textSize, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFont(t *testing.T) {
+	if err := loadFont(); err != nil {
+		t.Fatalf("loadFont: %v", err)
+	}
+	defer func() { tableFont = nil }()
+	if fontFace(labelSize) == nil {
+		t.Fatal("no face of the loaded font")
+	}
+	if w := measure("Hit", labelSize); w <= 0 {
+		t.Errorf(`measure("Hit") = %d with the TTF`, w)
+	}
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+const sampleRate = 44100
+
+type sound int
+
+const (
+	soundDeal sound = iota
+	soundHit
+	soundWin
+	soundBust
+)
+
+// sounds plays short synthesized effects. If the audio context can't be
+// created it stays silent rather than stopping the game.
+type sounds struct {
+	ctx   *audio.Context
+	clips map[sound][]byte
+
+	Volume float64
+	Muted  bool
+	// played, if set, is called with each effect played, even without an
+	// audio context.
+	played func(sound)
+}
+
+func newSounds() *sounds {
+	s := &sounds{Volume: 0.5}
+	ctx, err := newAudioContext()
+	if err != nil {
+		log.Printf("audio unavailable, running silently: %v", err)
+		return s
+	}
+	s.ctx = ctx
+	s.clips = map[sound][]byte{
+		soundDeal: synth(0.06, click(1)),
+		soundHit:  synth(0.08, click(0.7)),
+		soundWin:  synth(0.45, chime(660, 880)),
+		soundBust: synth(0.40, chime(220, 147)),
+	}
+	return s
+}
+
+// newAudioContext turns the panic audio.NewContext raises on failure into an
+// error.
+func newAudioContext() (ctx *audio.Context, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return audio.NewContext(sampleRate), nil
+}
+
+func (s *sounds) play(id sound) {
+	if s.Muted {
+		return
+	}
+	if s.played != nil {
+		s.played(id)
+	}
+	if s.ctx == nil {
+		return
+	}
+	p := s.ctx.NewPlayerFromBytes(s.clips[id])
+	p.SetVolume(s.Volume)
+	p.Play()
+}
+
+// synth renders wave over dur seconds as 16-bit little-endian stereo PCM.
+func synth(dur float64, wave func(t float64) float64) []byte {
+	n := int(dur * sampleRate)
+	buf := make([]byte, n*4)
+	for i := 0; i < n; i++ {
+		v := wave(float64(i) / sampleRate)
+		v = math.Max(-1, math.Min(1, v))
+		sample := uint16(int16(v * math.MaxInt16))
+		binary.LittleEndian.PutUint16(buf[i*4:], sample)
+		binary.LittleEndian.PutUint16(buf[i*4+2:], sample)
+	}
+	return buf
+}
+
+// click is a burst of quickly decaying noise, like a card landing on felt.
+func click(gain float64) func(t float64) float64 {
+	rng := rand.New(rand.NewSource(1))
+	return func(t float64) float64 {
+		return gain * (rng.Float64()*2 - 1) * math.Exp(-t*80)
+	}
+}
+
+// chime plays two decaying tones in sequence, rising or falling.
+func chime(first, second float64) func(t float64) float64 {
+	return func(t float64) float64 {
+		freq := first
+		if t >= 0.15 {
+			freq = second
+		}
+		return 0.4 * math.Sin(2*math.Pi*freq*t) * math.Exp(-t*6)
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// textInput is a one-line text box fed from the keyboard.
+type textInput struct {
+	text []rune
+	// max caps the length of the text.
+	max int
+	// accept, if set, filters the characters that may be typed.
+	accept func(r rune) bool
+	// chars is scratch space for the characters typed each frame.
+	chars []rune
+}
+
+// update takes this frame's typing and reports whether Enter was pressed.
+func (t *textInput) update() (submitted bool) {
+	t.chars = ebiten.AppendInputChars(t.chars[:0])
+	t.insert(t.chars...)
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		t.backspace()
+	}
+	return inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter)
+}
+
+func (t *textInput) insert(chars ...rune) {
+	for _, r := range chars {
+		if len(t.text) < t.max && (t.accept == nil || t.accept(r)) {
+			t.text = append(t.text, r)
+		}
+	}
+}
+
+func (t *textInput) backspace() {
+	if len(t.text) > 0 {
+		t.text = t.text[:len(t.text)-1]
+	}
+}
+
+func (t *textInput) String() string { return string(t.text) }
+
+// draw draws the box at x, y, wide enough for max characters, with a cursor
+// after the text.
+func (t *textInput) draw(screen *ebiten.Image, x, y int) {
+	w := measure(strings.Repeat("0", t.max+1), labelSize) + 8
+	vector.FillRect(screen, float32(x), float32(y), float32(w), glyphHeight+6, shadeColor, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(w), glyphHeight+6, 1, faceColor, false)
+	printAt(screen, t.String()+"_", x+4, y+3, labelSize)
+}
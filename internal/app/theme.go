@@ -0,0 +1,69 @@
+package app
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Theme is the look of the table: the felt and the back of face-down cards.
+type Theme struct {
+	Name      string
+	FeltColor color.Color
+	// CardBackImage, if set, is drawn for every face-down card, scaled to
+	// the card size. It belongs to the caller and is never deallocated by
+	// the App. Without one the back is drawn in BackColor.
+	CardBackImage *ebiten.Image
+	BackColor     color.Color
+}
+
+// Themes are the built-in themes T cycles through, the first being the
+// default.
+var Themes = []Theme{
+	{
+		Name:      "Classic",
+		FeltColor: color.RGBA{0x0b, 0x5d, 0x2e, 0xff},
+		BackColor: color.RGBA{0x8b, 0x1a, 0x1a, 0xff},
+	},
+	{
+		Name:      "Midnight",
+		FeltColor: color.RGBA{0x14, 0x24, 0x4a, 0xff},
+		BackColor: color.RGBA{0x2a, 0x2a, 0x2a, 0xff},
+	},
+}
+
+// SetTheme switches the table to t. A drawn card back is rendered into the
+// App's one back image, which is reused by every theme rather than
+// reallocated on each switch.
+func (a *App) SetTheme(t Theme) {
+	a.theme = t
+	if t.CardBackImage != nil {
+		return
+	}
+	if a.back == nil {
+		a.back = ebiten.NewImage(cardWidth, cardHeight)
+	}
+	a.back.Clear()
+	vector.FillRect(a.back, 0, 0, cardWidth, cardHeight, t.BackColor, false)
+	vector.StrokeRect(a.back, 4, 4, cardWidth-8, cardHeight-8, 1, faceColor, false)
+	vector.StrokeRect(a.back, 0, 0, cardWidth, cardHeight, 1, edgeColor, false)
+}
+
+// nextTheme switches to the built-in theme after the current one.
+func (a *App) nextTheme() {
+	a.themeIndex = (a.themeIndex + 1) % len(Themes)
+	a.SetTheme(Themes[a.themeIndex])
+}
+
+func (a *App) drawCardBack(screen *ebiten.Image, x, y int) {
+	img := a.theme.CardBackImage
+	if img == nil {
+		img = a.back
+	}
+	op := &ebiten.DrawImageOptions{}
+	size := img.Bounds().Size()
+	op.GeoM.Scale(float64(cardWidth)/float64(size.X), float64(cardHeight)/float64(size.Y))
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(img, op)
+}
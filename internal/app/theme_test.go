@@ -0,0 +1,18 @@
+package app
+
+import "testing"
+
+func TestSetThemeReusesCardBack(t *testing.T) {
+	a := &App{}
+	a.SetTheme(Themes[0])
+	back := a.back
+	for range len(Themes) * 2 {
+		a.nextTheme()
+	}
+	if a.back != back {
+		t.Error("switching themes allocated a new card back image")
+	}
+	if a.theme.Name != Themes[0].Name {
+		t.Errorf("cycled back to %q, want %q", a.theme.Name, Themes[0].Name)
+	}
+}
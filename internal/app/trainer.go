@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// countQuiz asks for the Hi-Lo running count as it stood when the quiz
+// opened.
+type countQuiz struct {
+	input textInput
+	want  int
+}
+
+func newCountQuiz(want int) *countQuiz {
+	return &countQuiz{
+		want: want,
+		input: textInput{
+			max: 4,
+			accept: func(r rune) bool {
+				return r == '-' || r == '+' || (r >= '0' && r <= '9')
+			},
+		},
+	}
+}
+
+// grade reports whether answer, e.g. "-3" or "+2", is the count.
+func (q *countQuiz) grade(answer string) bool {
+	n, err := strconv.Atoi(answer)
+	return err == nil && n == q.want
+}
+
+// quizScore tallies the count quizzes answered.
+type quizScore struct {
+	asked, correct int
+}
+
+func (s quizScore) String() string {
+	if s.asked == 0 {
+		return "Count quiz: none yet"
+	}
+	return fmt.Sprintf("Count quiz: %d/%d (%d%%)", s.correct, s.asked, s.correct*100/s.asked)
+}
+
+// roundEnded opens a count quiz every CountQuizEvery rounds.
+func (a *App) roundEnded() {
+	if a.CountQuizEvery <= 0 {
+		return
+	}
+	a.roundsSinceQuiz++
+	if a.roundsSinceQuiz >= a.CountQuizEvery {
+		a.roundsSinceQuiz = 0
+		a.quiz = newCountQuiz(a.game.Deck.RunningCount())
+	}
+}
+
+// updateQuiz feeds the open quiz the keyboard and grades the answer on
+// Enter.
+func (a *App) updateQuiz() {
+	q := a.quiz
+	if !q.input.update() || len(q.input.text) == 0 {
+		return
+	}
+	a.score.asked++
+	if q.grade(q.input.String()) {
+		a.score.correct++
+		a.quizResult = "Correct!"
+	} else {
+		a.quizResult = fmt.Sprintf("The count was %+d", q.want)
+	}
+	a.quiz = nil
+}
+
+// drawTrainer shows the open quiz in the middle of the table and the score
+// beneath the dealer's row.
+func (a *App) drawTrainer(screen *ebiten.Image) {
+	y := dealerY + cardHeight + 12
+	line := a.score.String()
+	if a.quizResult != "" {
+		line += "   " + a.quizResult
+	}
+	a.drawText(screen, line, tableMargin, y, textSize)
+	if a.quiz == nil {
+		return
+	}
+	const prompt = "Running count? Enter to answer"
+	x := (a.width - a.textWidth(prompt, textSize)) / 2
+	a.drawText(screen, prompt, x, y+a.lineHeight(), textSize)
+	a.quiz.input.draw(screen, x, y+2*a.lineHeight())
+}
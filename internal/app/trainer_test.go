@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+
+	"mock-jack/internal/game"
+)
+
+func TestCountQuizGrade(t *testing.T) {
+	q := newCountQuiz(-3)
+	q.input.insert([]rune("-3x9")...)
+	if got := q.input.String(); got != "-39" {
+		t.Errorf("typed %q, want the letter dropped: %q", got, "-39")
+	}
+	q.input.backspace()
+	if !q.grade(q.input.String()) {
+		t.Errorf("%q graded wrong for a count of -3", q.input.String())
+	}
+	for _, answer := range []string{"3", "+3", "-", ""} {
+		if q.grade(answer) {
+			t.Errorf("%q graded right for a count of -3", answer)
+		}
+	}
+	if !newCountQuiz(2).grade("+2") {
+		t.Error(`"+2" graded wrong for a count of 2`)
+	}
+}
+
+func TestQuizScoreString(t *testing.T) {
+	if got, want := (quizScore{asked: 4, correct: 3}).String(), "Count quiz: 3/4 (75%)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCountQuizOpensEveryNRounds(t *testing.T) {
+	// Two rounds of 10,9 against the dealer's 10,7.
+	var cards []game.Card
+	for range 2 {
+		cards = append(cards, game.Card{Rank: game.Ten}, game.Card{Rank: game.Ten}, game.Card{Rank: game.Nine}, game.Card{Rank: game.Seven})
+	}
+	g := game.NewGame(1)
+	g.Deck = game.NewStackedDeck(cards)
+	a := &App{game: g, sounds: &sounds{}, CountQuizEvery: 2}
+	g.OnStateChange = a.onStateChange
+	for round := 1; round <= 2; round++ {
+		if a.quiz != nil {
+			t.Fatalf("quiz open before round %d", round)
+		}
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerStand()
+	}
+	if a.quiz == nil {
+		t.Fatal("no quiz after 2 rounds with CountQuizEvery 2")
+	}
+	if a.quiz.want != g.Deck.RunningCount() {
+		t.Errorf("quiz wants %+d, the count is %+d", a.quiz.want, g.Deck.RunningCount())
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"context"
+	"time"
+)
+
+// RunDealer plays out the dealer's turn one card at a time, revealing the hole
+// card if RevealHoleCard hasn't, waiting delay before each draw and passing
+// every card drawn to onCard, if set, then settles the round. It does nothing
+// outside DealerTurn, so it is meant for games with ManualDealer set.
+//
+// If ctx is cancelled RunDealer returns ctx.Err() between draws, leaving the
+// game in DealerTurn with every card drawn so far in place; calling it again
+// resumes the turn.
+//
+// The lock is only held to draw each card, not through the delays, so other
+// goroutines can see the dealer's cards come out; onCard is called without
+// it.
+func (g *Game) RunDealer(ctx context.Context, delay time.Duration, onCard func(Card)) error {
+	for {
+		g.mu.Lock()
+		g.revealHoleCard()
+		done := g.State != DealerTurn
+		if !done && !g.dealerDraws() {
+			g.finishRound()
+			done = true
+		}
+		g.mu.Unlock()
+		if done {
+			return nil
+		}
+		select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+		}
+		g.mu.Lock()
+		drew := g.State == DealerTurn && g.dealerDraws()
+		var card Card
+		if drew {
+			card = g.dealerHit()
+		}
+		g.mu.Unlock()
+		if drew && onCard != nil {
+			onCard(card)
+		}
+	}
+}
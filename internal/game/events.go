@@ -0,0 +1,80 @@
+package game
+
+import "slices"
+
+// EventKind says what happened in an Event.
+type EventKind int
+
+const (
+	// EventDealt follows the opening deal, before a natural can end the
+	// round.
+	EventDealt EventKind = iota
+	// EventHit follows a PlayerHit; Card is the card drawn.
+	EventHit
+	// EventStand follows a PlayerStand.
+	EventStand
+	// EventRoundOver follows the settling of a round; Outcome holds the
+	// results.
+	EventRoundOver
+)
+
+func (k EventKind) String() string {
+	return map[EventKind]string{
+		EventDealt:     "Dealt",
+		EventHit:       "Hit",
+		EventStand:     "Stand",
+		EventRoundOver: "RoundOver",
+	}[k]
+}
+
+// Event is a notification sent to every Subscribe listener. Hand is the
+// index of the hand acted on, for EventHit and EventStand.
+type Event struct {
+	Kind    EventKind
+	Hand    int
+	Card    Card
+	Outcome RoundOutcome
+}
+
+type subscriber struct {
+	fn      func(Event)
+	removed bool
+}
+
+// Subscribe adds fn to the listeners called, synchronously and in the order
+// they subscribed, on every Event. The returned func removes it again; it is
+// safe to call at any time, including from a listener during dispatch, and a
+// listener removed mid-dispatch is not called for that event.
+func (g *Game) Subscribe(fn func(Event)) (unsubscribe func()) {
+	g.subMu.Lock()
+	defer g.subMu.Unlock()
+	s := &subscriber{fn: fn}
+	g.subscribers = append(g.subscribers, s)
+	return func() {
+		g.subMu.Lock()
+		defer g.subMu.Unlock()
+		if s.removed {
+			return
+		}
+		s.removed = true
+		// Replaced rather than edited in place, so a dispatch ranging over the
+		// old slice is undisturbed.
+		g.subscribers = slices.DeleteFunc(slices.Clone(g.subscribers), func(other *subscriber) bool {
+			return other == s
+		})
+	}
+}
+
+func (g *Game) emit(e Event) {
+	g.subMu.Lock()
+	subscribers := g.subscribers
+	g.subMu.Unlock()
+	for _, s := range subscribers {
+		g.subMu.Lock()
+		removed := s.removed
+		g.subMu.Unlock()
+		if !removed {
+			s.fn(e)
+		}
+	}
+}
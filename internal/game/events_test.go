@@ -0,0 +1,64 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSubscribeTwoListeners(t *testing.T) {
+	// Player 10,6 hits a 3 and stands on 19 against the dealer's 10,9.
+	g := stackedGame(DefaultRules(), Ten, Ten, Six, Nine, Three)
+	var first, second []EventKind
+	g.Subscribe(func(e Event) { first = append(first, e.Kind) })
+	var unsubscribe func()
+	unsubscribe = g.Subscribe(func(e Event) {
+		second = append(second, e.Kind)
+		if e.Kind == EventHit {
+			unsubscribe()
+			unsubscribe()
+		}
+	})
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerHit()
+	g.PlayerStand()
+
+	if want := []EventKind{EventDealt, EventHit, EventStand, EventRoundOver}; !slices.Equal(first, want) {
+		t.Errorf("first listener got %v, want %v", first, want)
+	}
+	if want := []EventKind{EventDealt, EventHit}; !slices.Equal(second, want) {
+		t.Errorf("listener that unsubscribed on the hit got %v, want %v", second, want)
+	}
+}
+
+func TestUnsubscribeLaterListenerDuringDispatch(t *testing.T) {
+	g := stackedGame(DefaultRules(), Ten, Ten, Six, Nine, Three)
+	var unsubscribeSecond func()
+	g.Subscribe(func(Event) { unsubscribeSecond() })
+	calls := 0
+	unsubscribeSecond = g.Subscribe(func(Event) { calls++ })
+	g.PlaceBet(10)
+	g.Deal()
+	if calls != 0 {
+		t.Errorf("listener removed earlier in the same dispatch was called %d times", calls)
+	}
+}
+
+func TestEventPayloads(t *testing.T) {
+	g := stackedGame(DefaultRules(), Ten, Ten, Six, Nine, Three)
+	var events []Event
+	g.Subscribe(func(e Event) { events = append(events, e) })
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerHit()
+	g.PlayerStand()
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	if hit := events[1]; hit.Card.Rank != Three || hit.Hand != 0 {
+		t.Errorf("EventHit = %+v, want the 3 on hand 0", hit)
+	}
+	if over := events[3]; over.Outcome.Net != 0 || len(over.Outcome.Hands) != 1 {
+		t.Errorf("EventRoundOver outcome = %+v, want a push", over.Outcome)
+	}
+}
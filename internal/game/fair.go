@@ -0,0 +1,116 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	randv2 "math/rand/v2"
+	"slices"
+)
+
+// fairSeeds are the inputs of a provably fair shuffle. The server seed is
+// kept out of saved games so a save can't reveal it early; a restored deck
+// only knows its commitment.
+type fairSeeds struct {
+	serverSeed string
+	Commitment string
+	ClientSeed string
+	Nonce      uint64
+}
+
+// NewProvablyFairDeck returns a deck whose shuffles are driven by a ChaCha8
+// stream keyed with the SHA-256 hash of serverSeed, clientSeed and nonce.
+// The hash is taken over each seed as its 8-byte big-endian length followed
+// by its bytes, then the nonce as 8 big-endian bytes, so no two sets of seeds
+// share a key. Publishing Commitment before play and RevealServerSeed after
+// lets a player rebuild the deck with the same arguments and check every
+// card dealt.
+func NewProvablyFairDeck(shoe int, serverSeed, clientSeed string, nonce uint64) *Deck {
+	d := NewDeckWithSource(shoe, newFairSource(serverSeed, clientSeed, nonce))
+	sum := sha256.Sum256([]byte(serverSeed))
+	d.fair = &fairSeeds{
+		serverSeed: serverSeed,
+		Commitment: hex.EncodeToString(sum[:]),
+		ClientSeed: clientSeed,
+		Nonce:      nonce,
+	}
+	return d
+}
+
+func newFairSource(serverSeed, clientSeed string, nonce uint64) fairSource {
+	var preimage []byte
+	for _, seed := range []string{serverSeed, clientSeed} {
+		preimage = binary.BigEndian.AppendUint64(preimage, uint64(len(seed)))
+		preimage = append(preimage, seed...)
+	}
+	preimage = binary.BigEndian.AppendUint64(preimage, nonce)
+	return fairSource{randv2.NewChaCha8(sha256.Sum256(preimage))}
+}
+
+// fairSource adapts a ChaCha8 stream to the Source a deck shuffles with, so
+// the whole 256-bit key, not a 64-bit seed, decides the shuffle.
+type fairSource struct {
+	*randv2.ChaCha8
+}
+
+func (s fairSource) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+// Seed is ignored: the key alone fixes the stream.
+func (s fairSource) Seed(int64) {}
+
+// Commitment returns the hex SHA-256 hash of the server seed of a provably
+// fair deck, or "" for any other deck.
+func (d *Deck) Commitment() string {
+	if d.fair == nil {
+		return ""
+	}
+	return d.fair.Commitment
+}
+
+// RevealServerSeed returns the server seed of a provably fair deck, to be
+// checked against Commitment once play is over, or "" for any other deck. A
+// deck restored from JSON never saved its server seed and returns "".
+func (d *Deck) RevealServerSeed() string {
+	if d.fair == nil {
+		return ""
+	}
+	return d.fair.serverSeed
+}
+
+// VerifyDeal reports whether expected is how a provably fair shoe built from
+// serverSeed, clientSeed and nonce starts, top card first: the cards burned
+// after the first shuffle, if any, then the cards dealt, as far as expected
+// goes; an empty expected proves nothing. The shoe size isn't needed; every
+// size up to MaxShoe is tried.
+//
+// The derivation, for reimplementing the check without this package:
+//
+//  1. The key is SHA-256 of len(serverSeed) as 8 big-endian bytes, the bytes
+//     of serverSeed, len(clientSeed) the same way, the bytes of clientSeed,
+//     and nonce as 8 big-endian bytes.
+//  2. The key seeds Go's ChaCha8 generator (math/rand/v2.NewChaCha8). Each
+//     63-bit value r is its next Uint64 shifted right by one bit, and each
+//     31-bit value is r shifted right by 32 more.
+//  3. The shoe is gathered clubs, diamonds, hearts, spades, each suit Ace to
+//     King, with each card repeated once per deck.
+//  4. It is shuffled for i from the last index down to 1 by swapping card i
+//     with card j, a number below i+1: a 31-bit value masked to its low bits
+//     if i+1 is a power of two, and otherwise the first 31-bit value no more
+//     than 2^31-1-(2^31 mod (i+1)) taken mod i+1.
+//  5. Cards come off the end of the shuffled shoe, so the last card is dealt
+//     first.
+func VerifyDeal(serverSeed, clientSeed string, nonce uint64, expected []Card) bool {
+	if len(expected) == 0 {
+		return false
+	}
+	for shoe := 1; shoe <= MaxShoe; shoe++ {
+		d := NewProvablyFairDeck(shoe, serverSeed, clientSeed, nonce)
+		if len(expected) > d.Remaining() {
+			continue
+		}
+		if slices.Equal(d.UpcomingOrder()[:len(expected)], expected) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	randv2 "math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProvablyFairDeck(t *testing.T) {
+	a := NewProvablyFairDeck(6, "server secret", "client", 7)
+	b := NewProvablyFairDeck(6, "server secret", "client", 7)
+	for i := 0; i < 2*a.Size(); i++ {
+		if ca, cb := a.Draw(), b.Draw(); ca != cb {
+			t.Fatalf("draw %d: %v and %v from the same seeds", i, ca, cb)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(a.RevealServerSeed()))
+	if a.RevealServerSeed() != "server secret" || a.Commitment() != hex.EncodeToString(sum[:]) {
+		t.Errorf("commitment %s doesn't match the revealed seed %q", a.Commitment(), a.RevealServerSeed())
+	}
+	if d := NewDeckWithSeed(1, 1); d.Commitment() != "" || d.RevealServerSeed() != "" {
+		t.Errorf("an ordinary deck has fair seeds")
+	}
+}
+
+func TestProvablyFairDeckSeedsDontRunTogether(t *testing.T) {
+	a := NewProvablyFairDeck(1, "a:b", "c", 0)
+	b := NewProvablyFairDeck(1, "a", "b:c", 0)
+	same := true
+	for i := 0; i < 52; i++ {
+		if a.Draw() != b.Draw() {
+			same = false
+		}
+	}
+	if same {
+		t.Error(`("a:b", "c") and ("a", "b:c") dealt the same shoe`)
+	}
+}
+
+func TestProvablyFairDeckJSONKeepsServerSeed(t *testing.T) {
+	d := NewProvablyFairDeck(1, "server secret", "client", 7)
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "server secret") {
+		t.Fatalf("saved deck reveals the server seed: %s", data)
+	}
+	var restored Deck
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Commitment() != d.Commitment() {
+		t.Errorf("restored commitment %s, want %s", restored.Commitment(), d.Commitment())
+	}
+}
+
+func TestVerifyDeal(t *testing.T) {
+	d := NewProvablyFairDeck(2, "server secret", "client", 7)
+	dealt := make([]Card, 20)
+	for i := range dealt {
+		dealt[i] = d.Draw()
+	}
+	if !VerifyDeal("server secret", "client", 7, dealt) {
+		t.Fatal("the honest deal didn't verify")
+	}
+
+	tampered := slices.Clone(dealt)
+	tampered[5] = Card{Rank: tampered[5].Rank%13 + 1, Suit: tampered[5].Suit}
+	if VerifyDeal("server secret", "client", 7, tampered) {
+		t.Error("a tampered card verified")
+	}
+	if VerifyDeal("server secret", "client", 8, dealt) || VerifyDeal("server secret", "other", 7, dealt) {
+		t.Error("the deal verified against the wrong seeds")
+	}
+	if VerifyDeal("server secret", "client", 7, nil) {
+		t.Error("an empty deal verified")
+	}
+}
+
+// TestVerifyDealDerivation follows VerifyDeal's documented steps without the
+// package's deck, so the doc comment stays accurate.
+func TestVerifyDealDerivation(t *testing.T) {
+	server, client, nonce := "server secret", "client", uint64(7)
+	var pre []byte
+	pre = binary.BigEndian.AppendUint64(pre, uint64(len(server)))
+	pre = append(pre, server...)
+	pre = binary.BigEndian.AppendUint64(pre, uint64(len(client)))
+	pre = append(pre, client...)
+	pre = binary.BigEndian.AppendUint64(pre, nonce)
+	rng := randv2.NewChaCha8(sha256.Sum256(pre))
+	int31 := func() uint32 { return uint32(rng.Uint64() >> 1 >> 32) }
+
+	var shoe []Card
+	for _, s := range []Suit{Clubs, Diamonds, Hearts, Spades} {
+		for r := Ace; r <= King; r++ {
+			shoe = append(shoe, Card{Rank: r, Suit: s})
+		}
+	}
+	for i := len(shoe) - 1; i > 0; i-- {
+		n := uint32(i + 1)
+		var j uint32
+		if n&(n-1) == 0 {
+			j = int31() & (n - 1)
+		} else {
+			limit := uint32(1<<31-1) - uint32(1<<31)%n
+			v := int31()
+			for v > limit {
+				v = int31()
+			}
+			j = v % n
+		}
+		shoe[i], shoe[j] = shoe[j], shoe[i]
+	}
+	slices.Reverse(shoe)
+
+	if got := NewProvablyFairDeck(1, server, client, nonce).UpcomingOrder(); !slices.Equal(got, shoe) {
+		t.Fatalf("documented derivation dealt %v, want %v", shoe, got)
+	}
+	if !VerifyDeal(server, client, nonce, shoe) {
+		t.Error("the documented derivation didn't verify")
+	}
+}
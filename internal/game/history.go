@@ -0,0 +1,114 @@
+package game
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoundRecord is the log of one completed round. A split round is a single
+// record whose Hands holds every hand the player finished with.
+type RoundRecord struct {
+	Started  time.Time
+	Finished time.Time
+	// Hands are the player's final hands, each with its own cards and bet.
+	Hands  []Hand
+	Dealer Hand
+	// Actions are the player's decisions in the order taken, across all
+	// hands.
+	Actions []Action
+	Insured bool
+	Result  string
+	Outcome RoundOutcome
+}
+
+// History returns a copy of the records of every round finished since the
+// game started or ClearHistory was last called, oldest first.
+func (g *Game) History() []RoundRecord {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.history)
+}
+
+// ClearHistory forgets the rounds played, starting BankrollHistory again
+// from the current bankroll.
+func (g *Game) ClearHistory() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.history = nil
+	g.BankrollHistory = []int{g.Bankroll}
+}
+
+// BankrollSeries returns a copy of BankrollHistory: the bankroll the history
+// starts from, then the bankroll after each round. So it holds one point
+// more than History has rounds.
+func (g *Game) BankrollSeries() []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.BankrollHistory)
+}
+
+func (g *Game) recordRound() {
+	hands := make([]Hand, len(g.PlayerHands))
+	for i, hand := range g.PlayerHands {
+		hand.Cards = slices.Clone(hand.Cards)
+		hands[i] = hand
+	}
+	dealer := g.Dealer
+	dealer.Cards = slices.Clone(dealer.Cards)
+	g.history = append(g.history, RoundRecord{
+		Started:  g.started,
+		Finished: time.Now(),
+		Hands:    hands,
+		Dealer:   dealer,
+		Actions:  slices.Clone(g.actions),
+		Insured:  g.Insured,
+		Result:   g.Result,
+		Outcome:  g.Outcome,
+	})
+	if len(g.history) == 1 {
+		// The bankroll may have been set since the series started, so the
+		// first round starts it again from the bankroll the round began with.
+		g.BankrollHistory = append(g.BankrollHistory[:0], g.Bankroll-g.Outcome.Net)
+	}
+	g.BankrollHistory = append(g.BankrollHistory, g.Bankroll)
+	if g.recorder != nil {
+		g.recorder.record(g)
+	}
+}
+
+// ExportCSV writes the history as CSV with a header row and one row per
+// round. The hands of a split round share a row, their cards and totals
+// separated by " | ", and the bet is their combined stake.
+func (g *Game) ExportCSV(w io.Writer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"started", "player_cards", "dealer_cards", "player_total", "dealer_total", "result", "bet"})
+	for _, r := range g.history {
+		cards := make([]string, len(r.Hands))
+		totals := make([]string, len(r.Hands))
+		bet := 0
+		for i, hand := range r.Hands {
+			value, _ := hand.Value()
+			cards[i] = hand.String()
+			totals[i] = strconv.Itoa(value)
+			bet += hand.Bet
+		}
+		dealer, _ := r.Dealer.Value()
+		cw.Write([]string{
+			r.Started.Format(time.RFC3339),
+			strings.Join(cards, " | "),
+			r.Dealer.String(),
+			strings.Join(totals, " | "),
+			strconv.Itoa(dealer),
+			r.Result,
+			strconv.Itoa(bet),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
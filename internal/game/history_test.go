@@ -0,0 +1,94 @@
+package game
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	g := NewGameWithSeed(1, 5)
+	for i := 0; i < 3; i++ {
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerStand()
+	}
+	var buf bytes.Buffer
+	if err := g.ExportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want a header and 3 rounds", len(rows))
+	}
+	for i, r := range g.History() {
+		if got := rows[i+1][5]; got != r.Result {
+			t.Errorf("row %d result = %q, want %q", i+1, got, r.Result)
+		}
+	}
+}
+
+func TestExportCSVSplitRound(t *testing.T) {
+	// 8,8 against the dealer's 10,7 splits into two hands that each draw a
+	// 10 and stand on 18; the summary of the two results has a comma.
+	g := stackedGame(DefaultRules(), Eight, Ten, Eight, Seven, Ten, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	g.PlayerStand()
+	g.PlayerStand()
+	result := g.History()[0].Result
+	if !strings.Contains(result, ",") {
+		t.Fatalf("split round result %q has no comma to quote", result)
+	}
+	var buf bytes.Buffer
+	if err := g.ExportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want a header and 1 round", len(rows))
+	}
+	want := []string{"8♠ 10♠ | 8♠ 10♠", "10♠ 7♠", "18 | 18", "17", result, "20"}
+	if got := rows[1]; len(got) != 7 || !slices.Equal(got[1:], want) {
+		t.Errorf("row = %q, want 7 fields ending %q", got, want)
+	}
+}
+
+func TestBankrollSeries(t *testing.T) {
+	// Three hands standing against the dealer: 19 vs 18 wins, 17 vs 19
+	// loses and 18 vs 18 pushes.
+	g := stackedGame(DefaultRules(),
+		Ten, Ten, Nine, Eight,
+		Ten, Ten, Seven, Nine,
+		Ten, Ten, Eight, Eight)
+	if got, want := g.BankrollSeries(), []int{DefaultBankroll}; !slices.Equal(got, want) {
+		t.Errorf("series before any round = %v, want %v", got, want)
+	}
+	for i := 0; i < 3; i++ {
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerStand()
+	}
+	want := []int{DefaultBankroll, DefaultBankroll + 10, DefaultBankroll, DefaultBankroll}
+	series := g.BankrollSeries()
+	if !slices.Equal(series, want) {
+		t.Errorf("BankrollSeries() = %v, want %v", series, want)
+	}
+	series[0] = 0
+	if g.BankrollHistory[0] != DefaultBankroll {
+		t.Error("changing the returned series changed the game's")
+	}
+	g.ClearHistory()
+	if got, want := g.BankrollSeries(), []int{DefaultBankroll}; !slices.Equal(got, want) {
+		t.Errorf("series after ClearHistory = %v, want %v", got, want)
+	}
+}
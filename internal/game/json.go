@@ -0,0 +1,246 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"time"
+)
+
+func (c Card) MarshalText() ([]byte, error) {
+	return []byte(c.Code()), nil
+}
+
+func (c *Card) UnmarshalText(text []byte) error {
+	card, err := ParseCard(string(text))
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
+
+type deckJSON struct {
+	Cards             []Card
+	Shoe              int
+	Size              int
+	Stacked           bool
+	Penetration       float64
+	ContinuousShuffle bool
+	BurnCards         int
+	StrictDeck        bool
+	Burned            []Card
+	Discard           []Card
+	NeedsShuffle      bool
+	RunningCount      int
+	Seeded            bool
+	Seed              int64
+	RNGCalls          uint64
+	Fair              *fairSeeds
+}
+
+// MarshalJSON saves the remaining cards in draw order along with the seed
+// and position of the shuffle source. Decks built with NewDeckWithSource
+// have no known seed and reshuffle from a fresh time-based seed once
+// restored. So do provably fair decks, which save their commitment but never
+// their server seed. ShuffleFunc and OnShuffle are not saved.
+func (d *Deck) MarshalJSON() ([]byte, error) {
+	v := deckJSON{
+		Cards:             d.cards,
+		Shoe:              d.shoe,
+		Size:              d.size,
+		Stacked:           d.stacked,
+		Penetration:       d.Penetration,
+		ContinuousShuffle: d.ContinuousShuffle,
+		BurnCards:         d.BurnCards,
+		StrictDeck:        d.StrictDeck,
+		Burned:            d.burned,
+		Discard:           d.discard,
+		NeedsShuffle:      d.needsShuffle,
+		RunningCount:      d.running,
+		Seeded:            d.seeded,
+		Seed:              d.seed,
+		Fair:              d.fair,
+	}
+	if d.src != nil {
+		v.RNGCalls = d.src.n
+	}
+	return json.Marshal(v)
+}
+
+func (d *Deck) UnmarshalJSON(data []byte) error {
+	var v deckJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = Deck{
+		cards:             v.Cards,
+		shoe:              v.Shoe,
+		size:              v.Size,
+		stacked:           v.Stacked,
+		Penetration:       v.Penetration,
+		ContinuousShuffle: v.ContinuousShuffle,
+		BurnCards:         v.BurnCards,
+		StrictDeck:        v.StrictDeck,
+		burned:            v.Burned,
+		discard:           v.Discard,
+		needsShuffle:      v.NeedsShuffle,
+		running:           v.RunningCount,
+		seeded:            v.Seeded,
+		seed:              v.Seed,
+		fair:              v.Fair,
+	}
+	if d.stacked {
+		return nil
+	}
+	if !d.seeded {
+		v.Seed = time.Now().UnixNano()
+		v.RNGCalls = 0
+	}
+	d.src = &countingSource{src: rand.NewSource(v.Seed)}
+	for d.src.n < v.RNGCalls {
+		d.src.Int63()
+	}
+	d.rng = rand.New(d.src)
+	return nil
+}
+
+type gameJSON struct {
+	Deck        *Deck
+	PlayerHands []Hand
+	Active      int
+	Dealer      Hand
+	State       State
+	Result      string
+	Outcome     RoundOutcome
+	Insured     bool
+	Bankroll    int
+	Bet         int
+	Bets        []int
+
+	Rules            Rules
+	ManualDealer     bool
+	SidePerfectPairs int
+	Side213          int
+
+	HoleRevealed  bool
+	InsuranceOpen bool
+	InsuranceBet  int
+	BetPlaced     bool
+	Stats         Stats
+	History       []RoundRecord
+	Bankrolls     []int
+	Actions       []Action
+	Started       time.Time
+	SideBets      []SideBetOutcome
+	Undo          []undoStep
+}
+
+type undoStepJSON struct {
+	Active       int
+	Drew         bool
+	Card         Card
+	NeedsShuffle bool
+}
+
+func (s undoStep) MarshalJSON() ([]byte, error) {
+	return json.Marshal(undoStepJSON{
+		Active:       s.active,
+		Drew:         s.drew,
+		Card:         s.card,
+		NeedsShuffle: s.needsShuffle,
+	})
+}
+
+func (s *undoStep) UnmarshalJSON(data []byte) error {
+	var v undoStepJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = undoStep{
+		active:       v.Active,
+		drew:         v.Drew,
+		card:         v.Card,
+		needsShuffle: v.NeedsShuffle,
+	}
+	return nil
+}
+
+// MarshalJSON saves the full game, including the deck and its shuffle
+// position and what Undo can still revert, so it can be resumed with
+// LoadJSON. Callbacks and subscribers are not saved, and neither is the
+// Recorder of a game from NewRecordedGame: a restored game records nothing.
+func (g *Game) MarshalJSON() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return json.Marshal(gameJSON{
+		Deck:             g.Deck,
+		PlayerHands:      g.PlayerHands,
+		Active:           g.Active,
+		Dealer:           g.Dealer,
+		State:            g.State,
+		Result:           g.Result,
+		Outcome:          g.Outcome,
+		Insured:          g.Insured,
+		Bankroll:         g.Bankroll,
+		Bet:              g.Bet,
+		Bets:             g.Bets,
+		Rules:            g.Rules,
+		ManualDealer:     g.ManualDealer,
+		SidePerfectPairs: g.SidePerfectPairs,
+		Side213:          g.Side213,
+		HoleRevealed:     g.holeRevealed,
+		InsuranceOpen:    g.insuranceOpen,
+		InsuranceBet:     g.insuranceBet,
+		BetPlaced:        g.betPlaced,
+		Stats:            g.stats,
+		History:          g.history,
+		Bankrolls:        g.BankrollHistory,
+		Actions:          g.actions,
+		Started:          g.started,
+		SideBets:         g.sideBets,
+		Undo:             g.undo,
+	})
+}
+
+func (g *Game) UnmarshalJSON(data []byte) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var v gameJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	g.Deck = v.Deck
+	g.PlayerHands = v.PlayerHands
+	g.Active = v.Active
+	g.Dealer = v.Dealer
+	g.State = v.State
+	g.Result = v.Result
+	g.Outcome = v.Outcome
+	g.Insured = v.Insured
+	g.Bankroll = v.Bankroll
+	g.Bet = v.Bet
+	g.Bets = v.Bets
+	g.Rules = v.Rules
+	g.ManualDealer = v.ManualDealer
+	g.SidePerfectPairs = v.SidePerfectPairs
+	g.Side213 = v.Side213
+	g.holeRevealed = v.HoleRevealed
+	g.insuranceOpen = v.InsuranceOpen
+	g.insuranceBet = v.InsuranceBet
+	g.betPlaced = v.BetPlaced
+	g.stats = v.Stats
+	g.history = v.History
+	g.BankrollHistory = v.Bankrolls
+	g.actions = v.Actions
+	g.started = v.Started
+	g.sideBets = v.SideBets
+	g.undo = v.Undo
+	g.recorder = nil
+	return nil
+}
+
+// LoadJSON restores a game saved with MarshalJSON in place. Callbacks and
+// subscribers already set on g are kept.
+func (g *Game) LoadJSON(data []byte) error {
+	return json.Unmarshal(data, g)
+}
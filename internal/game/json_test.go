@@ -0,0 +1,57 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGameJSONRoundTripMidHand(t *testing.T) {
+	g := NewGameWithSeed(2, 42)
+	for i := 0; i < 30; i++ {
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayPlayerWith(BasicStrategyPlayer{})
+	}
+	// Deal on to a round the player can act in, and hit once so there is
+	// something to undo.
+	for {
+		g.PlaceBet(10)
+		g.Deal()
+		g.DeclineInsurance()
+		if g.State == PlayerTurn {
+			g.PlayerHit()
+		}
+		if g.State == PlayerTurn {
+			break
+		}
+		g.PlayPlayerWith(BasicStrategyPlayer{})
+	}
+	data, err := g.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored Game
+	if err := restored.LoadJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	again, err := restored.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Fatalf("restored game saves differently:\n%s\n%s", data, again)
+	}
+	if !restored.Undo() {
+		t.Fatal("restored game has nothing to undo")
+	}
+	g.Undo()
+	if got, want := restored.ActiveHand().String(), g.ActiveHand().String(); got != want {
+		t.Errorf("restored hand after Undo = %s, want %s", got, want)
+	}
+	for i := 0; i < 200; i++ {
+		if a, b := g.Deck.Draw(), restored.Deck.Draw(); a != b {
+			t.Fatalf("draw %d: %v from the original, %v once restored", i, a, b)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import "fmt"
+
+// MessageKey names a message shown to the player. Its arguments, listed with
+// each key, fill in the verbs of the English text.
+type MessageKey string
+
+const (
+	MsgPushBlackjack   MessageKey = "push-blackjack"
+	MsgPush            MessageKey = "push" // player total, dealer total
+	MsgPlayerBlackjack MessageKey = "player-blackjack"
+	MsgDealerBusts     MessageKey = "dealer-busts" // dealer total
+	MsgPlayerWins      MessageKey = "player-wins"  // player total, dealer total
+	MsgPlayerBusts     MessageKey = "player-busts" // player total
+	MsgDealerBlackjack MessageKey = "dealer-blackjack"
+	MsgDealerWins      MessageKey = "dealer-wins" // dealer total, player total
+	MsgSurrender       MessageKey = "surrender"
+	MsgEvenMoney       MessageKey = "even-money"
+	MsgCharlie         MessageKey = "charlie"
+	MsgDealerWinsTie   MessageKey = "dealer-wins-tie"
+
+	MsgHand     MessageKey = "hand"      // hand number
+	MsgSeat     MessageKey = "seat"      // seat number
+	MsgSeatHand MessageKey = "seat-hand" // seat number, hand number
+
+	MsgInsuranceWon  MessageKey = "insurance-won"
+	MsgInsuranceLost MessageKey = "insurance-lost"
+	MsgSideBetWon    MessageKey = "side-bet-won"  // name, category, multiplier
+	MsgSideBetLost   MessageKey = "side-bet-lost" // name
+
+	// A round of several hands is summed up from these: each hand's label
+	// and word, the insurance, and the net in units of the bet.
+	MsgSummaryWin           MessageKey = "summary-win"       // label
+	MsgSummaryLoss          MessageKey = "summary-loss"      // label
+	MsgSummaryPush          MessageKey = "summary-push"      // label
+	MsgSummarySurrender     MessageKey = "summary-surrender" // label
+	MsgSummaryInsuranceWon  MessageKey = "summary-insurance-won"
+	MsgSummaryInsuranceLost MessageKey = "summary-insurance-lost"
+	MsgSummaryNet           MessageKey = "summary-net" // joined parts, units
+)
+
+// Localizer turns a message key and its arguments into text. Set
+// Game.Localizer to one to settle rounds in another language.
+type Localizer interface {
+	Message(key MessageKey, args ...any) string
+}
+
+// Messages is a Localizer from fmt format strings. A key it lacks comes out
+// as the key itself.
+type Messages map[MessageKey]string
+
+func (m Messages) Message(key MessageKey, args ...any) string {
+	format, ok := m[key]
+	if !ok {
+		return string(key)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// English is the default Localizer.
+var English = Messages{
+	MsgPushBlackjack:   "Push — both blackjack.",
+	MsgPush:            "Push. (%d vs %d)",
+	MsgPlayerBlackjack: "Player blackjack!",
+	MsgDealerBusts:     "Dealer busts (%d). Player wins!",
+	MsgPlayerWins:      "Player wins! (%d vs %d)",
+	MsgPlayerBusts:     "Player busts (%d). Dealer wins.",
+	MsgDealerBlackjack: "Dealer blackjack.",
+	MsgDealerWins:      "Dealer wins. (%d vs %d)",
+	MsgSurrender:       "Player surrenders (loses half bet).",
+	MsgEvenMoney:       "Even money. Player wins 1:1.",
+	MsgCharlie:         "Five-card Charlie! Player wins.",
+	MsgDealerWinsTie:   "Tie. Dealer wins ties.",
+
+	MsgHand:     "Hand %d",
+	MsgSeat:     "Seat %d",
+	MsgSeatHand: "Seat %d hand %d",
+
+	MsgInsuranceWon:  "Insurance pays 2:1.",
+	MsgInsuranceLost: "Insurance lost.",
+	MsgSideBetWon:    "%s: %s pays %d:1.",
+	MsgSideBetLost:   "%s lost.",
+
+	MsgSummaryWin:           "%s win",
+	MsgSummaryLoss:          "%s loss",
+	MsgSummaryPush:          "%s push",
+	MsgSummarySurrender:     "%s surrender",
+	MsgSummaryInsuranceWon:  "insurance won",
+	MsgSummaryInsuranceLost: "insurance lost",
+	MsgSummaryNet:           "%s; net %+.1f",
+}
+
+// localizer returns the game's Localizer, English if none is set.
+func (g *Game) localizer() Localizer {
+	if g.Localizer == nil {
+		return English
+	}
+	return g.Localizer
+}
+
+// message renders key with int arguments, as hand results carry them.
+func message(l Localizer, key MessageKey, args []int) string {
+	vals := make([]any, len(args))
+	for i, a := range args {
+		vals[i] = a
+	}
+	return l.Message(key, vals...)
+}
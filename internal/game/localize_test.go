@@ -0,0 +1,52 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// keyLocalizer writes every message as its key and arguments.
+type keyLocalizer struct{}
+
+func (keyLocalizer) Message(key MessageKey, args ...any) string {
+	return fmt.Sprint(string(key), args)
+}
+
+func TestStubLocalizer(t *testing.T) {
+	// Player 10,9 stands against the dealer's 10,8.
+	g := stackedGame(DefaultRules(), Ten, Ten, Nine, Eight)
+	g.Localizer = keyLocalizer{}
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerStand()
+	if want := "player-wins[19 18]"; g.Result != want {
+		t.Errorf("Result = %q, want %q", g.Result, want)
+	}
+	if want := "Player wins! (19 vs 18)"; g.Outcome.String() != want {
+		t.Errorf("Outcome.String() = %q, want English %q", g.Outcome.String(), want)
+	}
+}
+
+func TestStubLocalizerSplitSummary(t *testing.T) {
+	// 8,8 against the dealer's 10,9: both split hands stand on 18 and lose.
+	g := stackedGame(DefaultRules(), Eight, Ten, Eight, Nine, Ten, Ten)
+	g.Localizer = keyLocalizer{}
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	g.PlayerStand()
+	g.PlayerStand()
+	if want := "summary-net[summary-loss[hand[1]], summary-loss[hand[2]] -2]"; g.Result != want {
+		t.Errorf("Result = %q, want %q", g.Result, want)
+	}
+}
+
+func TestMessagesMissingKey(t *testing.T) {
+	m := Messages{MsgPush: "Égalité (%d contre %d)"}
+	if got := m.Message(MsgPush, 17, 17); got != "Égalité (17 contre 17)" {
+		t.Errorf("Message(MsgPush) = %q", got)
+	}
+	if got := m.Message(MsgCharlie); got != string(MsgCharlie) {
+		t.Errorf("Message of a missing key = %q, want the key", got)
+	}
+}
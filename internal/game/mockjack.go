@@ -1,8 +1,13 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 )
 
@@ -15,6 +20,15 @@ const (
 	RoundOver
 )
 
+func (s State) String() string {
+	return map[State]string{
+		WaitingDeal: "Waiting for deal",
+		PlayerTurn:  "Player's turn",
+		DealerTurn:  "Dealer's turn",
+		RoundOver:   "Round over",
+	}[s]
+}
+
 type Suit int
 type Rank int
 
@@ -73,11 +87,73 @@ func (c Card) String() string {
 	return rank + suit
 }
 
+// Equal reports whether two cards have the same suit and rank.
+func (c Card) Equal(o Card) bool {
+	return c.Suit == o.Suit && c.Rank == o.Rank
+}
+
+// Color is the color of a card's suit.
+type Color int
+
+const (
+	Black Color = iota
+	Red
+)
+
+func (c Color) String() string {
+	if c == Red {
+		return "Red"
+	}
+	return "Black"
+}
+
+// Color returns Red for hearts and diamonds and Black for clubs and spades.
+func (c Card) Color() Color {
+	if c.Suit == Hearts || c.Suit == Diamonds {
+		return Red
+	}
+	return Black
+}
+
+// BlackjackValue is the card's point value: its number for 2 to 9, 10 for ten
+// through king, and 11 for an ace, which is its high value; a hand counts an
+// ace as 1 when 11 would bust it.
+func (c Card) BlackjackValue() int {
+	switch {
+		case c.Rank == Ace:
+			return 11
+		case c.Rank >= Ten:
+			return 10
+		default:
+			return int(c.Rank)
+	}
+}
+
 type Hand struct {
 	Cards []Card
+	// Bet is the stake riding on the hand, including any double.
+	Bet int
+	// Seat is the table seat the hand is played from.
+	Seat int
+	// Doubled records that the wager on this hand was doubled down.
+	Doubled bool
+	// Surrendered records that the hand was given up for half its bet.
+	Surrendered bool
+	// EvenMoney records that the natural was paid 1:1 against a dealer Ace.
+	EvenMoney bool
+	// FromSplit records that the hand was made by splitting a pair.
+	FromSplit bool
 }
 
-func (h *Hand) Clear() { h.Cards = h.Cards[:0] }
+func (h *Hand) Clear() {
+	h.Cards = h.Cards[:0]
+	h.Bet = 0
+	h.Seat = 0
+	h.Doubled = false
+	h.Surrendered = false
+	h.EvenMoney = false
+	h.FromSplit = false
+}
 func (h *Hand) Add(c Card) { h.Cards = append(h.Cards, c) }
 func (h Hand) String() string {
 	if len(h.Cards) == 0 { return "<empty>" }
@@ -91,65 +167,211 @@ func (h Hand) String() string {
 
 // Value computes the blackjack value of the hand and whether it is a soft hand.
 func (h Hand) Value() (best int, isSoft bool) {
-	total := 0
-	aces := 0
-	for _, card := range h.Cards {
-		switch {
-			case card.Rank == Ace:
-				aces++
-				total += 11
-			case card.Rank >= Ten:
-				total += 10
-			default:
-				total += int(card.Rank)
-		}
-	}
+	_, best, isSoft = h.Totals()
+	return best, isSoft
+}
 
-	for total > 21 && aces > 0 {
-		total -= 10
-		aces--
+// ValueString formats the hand's value for display: "BJ" for a natural,
+// "Bust" over 21, "soft 17" for a soft total and "16" for a hard one.
+func (h Hand) ValueString() string {
+	value, isSoft := h.Value()
+	switch {
+		case h.IsBlackjack():
+			return "BJ"
+		case value > 21:
+			return "Bust"
+		case isSoft:
+			return "soft " + strconv.Itoa(value)
+		default:
+			return strconv.Itoa(value)
 	}
+}
 
-	// If there are remaining aces counted as 11, it's a soft hand.
-	isSoft = false
-	hard := 0
-	aceCount := 0
+// Totals returns the hand's hard total (every ace counted as 1) and its soft
+// total (one ace counted as 11 when that doesn't bust, otherwise equal to
+// hard), and whether the soft total is in use. Two aces can never both count
+// as 11, so promoting one is enough however many the hand holds: A,A,9 is a
+// soft 21 and A,A,9,9 a hard 20.
+func (h Hand) Totals() (hard int, soft int, isSoft bool) {
+	aces := 0
 	for _, card := range h.Cards {
-		if card.Rank == Ace {
-			aceCount++
-			hard += 1
-		} else if card.Rank >= Ten {
-			hard += 10
-		} else {
-			hard += int(card.Rank)
+		value := card.BlackjackValue()
+		if value == 11 {
+			aces++
+			value = 1
 		}
+		hard += value
 	}
-	if aceCount > 0 && hard+10 <= 21 {
+
+	soft = hard
+	if aces > 0 && hard+10 <= 21 {
+		soft = hard + 10
 		isSoft = true
 	}
+	return hard, soft, isSoft
+}
+
+// IsBust reports whether the hand's best total exceeds 21.
+func (h Hand) IsBust() bool {
+	value, _ := h.Value()
+	return value > 21
+}
 
-	return total, isSoft
+// CanSplit reports whether the hand is a pair that may be split: exactly two
+// cards of equal Rank. Mixed ten-value cards such as K+Q do not count as a
+// pair.
+func (h Hand) CanSplit() bool {
+	return len(h.Cards) == 2 && h.Cards[0].Rank == h.Cards[1].Rank
+}
+
+// IsBlackjack reports whether the hand is a natural: exactly two cards
+// totaling 21, not made by a split.
+func (h Hand) IsBlackjack() bool {
+	value, _ := h.Value()
+	return len(h.Cards) == 2 && value == 21 && !h.FromSplit
 }
 
 type Deck struct {
 	cards []Card
 	rng *rand.Rand
+	src *countingSource
+	// seed is the seed of src when the deck was built from one, so the
+	// shuffle sequence can be restored.
+	seed   int64
+	seeded bool
+	// fair holds the seeds of a provably fair deck.
+	fair *fairSeeds
 	shoe int
+	size int
+	stacked bool
+
+	// Penetration is the fraction of the shoe dealt before the cut card comes
+	// out, e.g. 0.75. Zero deals the shoe to the last card.
+	Penetration float64
+	// ContinuousShuffle reshuffles the whole shoe before every round, as a
+	// continuous shuffling machine does. It takes precedence over
+	// Penetration, which is then ignored. A Game sets these three from its
+	// Rules on every deal.
+	ContinuousShuffle bool
+	// BurnCards is the number of cards discarded face down after each
+	// shuffle.
+	BurnCards int
+	// StrictDeck makes Draw panic on an empty shoe instead of reshuffling a
+	// new one mid-round, which would change the shoe's makeup under a count.
+	// A Game dealing from a strict deck reshuffles between rounds when the
+	// shoe can't cover the deal.
+	StrictDeck bool
+	// ShuffleFunc, if set, shuffles the shoe in place of FisherYates, e.g. to
+	// model an imperfect riffle. It is used from the next shuffle on; the
+	// shoe a deck is built with has already been shuffled.
+	ShuffleFunc func(cards []Card, rng *rand.Rand)
+	// OnShuffle, if set, is called each time the shoe is gathered up and
+	// reshuffled, once the new shoe is ready: between rounds when the cut
+	// card is out, or mid-round when the shoe runs out. The running count
+	// starts again from zero.
+	OnShuffle func()
+	burned    []Card
+	// discard holds the cards drawn or burned since the last shuffle,
+	// whether still on the table or in the discard tray.
+	discard      []Card
+	needsShuffle bool
+	// running is the Hi-Lo count of the cards drawn since the last shuffle.
+	running int
 }
 
 func NewDeck(shoe int) *Deck {
-	if shoe < 1 {
-		shoe = 1
-	}
+	return NewDeckWithSeed(shoe, time.Now().UnixNano())
+}
+
+// NewDeckWithSeed returns a deck whose shuffles are fully determined by seed.
+func NewDeckWithSeed(shoe int, seed int64) *Deck {
+	d := NewDeckWithSource(shoe, rand.NewSource(seed))
+	d.seed = seed
+	d.seeded = true
+	return d
+}
+
+// NewDeckWithSource returns a deck shuffled with randomness drawn from src,
+// e.g. a cryptographically secure or recorded source. Shoe sizes are clamped
+// to between 1 and MaxShoe decks.
+func NewDeckWithSource(shoe int, src rand.Source) *Deck {
+	shoe = min(max(shoe, 1), MaxShoe)
 	d := &Deck{
 		shoe: shoe,
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		size: 52 * shoe,
+		src: &countingSource{src: src},
 	}
+	d.rng = rand.New(d.src)
 	d.reset()
 	return d
 }
 
+// countingSource counts the values drawn from a source so a seeded source
+// can later be fast-forwarded to the same position.
+type countingSource struct {
+	src rand.Source
+	n   uint64
+}
+
+func (s *countingSource) Int63() int64 {
+	s.n++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.n = 0
+}
+
+// NewStackedDeck returns a deck that deals exactly the given cards, cards[0]
+// first. It is never shuffled, and drawing past the last card panics so a
+// scenario that runs short fails loudly instead of dealing random cards.
+func NewStackedDeck(cards []Card) *Deck {
+	d := &Deck{
+		shoe: 1,
+		size: len(cards),
+		stacked: true,
+	}
+	d.cards = make([]Card, len(cards))
+	for i, c := range cards {
+		d.cards[len(cards)-1-i] = c
+	}
+	return d
+}
+
+// NewDeckFromOrder rebuilds a shoe whose remaining cards are order, top
+// first, as returned by UpcomingOrder. Unlike a stacked deck it plays on as
+// a normal shoe of as many decks as order needs: once drawn out it is
+// reshuffled from a time-based seed. The running count is that of the cards
+// missing from the full shoe.
+func NewDeckFromOrder(order []Card) *Deck {
+	d := NewDeck((len(order) + 51) / 52)
+	d.cards = d.cards[:0]
+	d.running = 0
+	for i := len(order) - 1; i >= 0; i-- {
+		d.cards = append(d.cards, order[i])
+		d.running -= hiLo(order[i])
+	}
+	d.burned, d.discard = nil, nil
+	return d
+}
+
+// UpcomingOrder returns a copy of the cards left in the shoe in the order
+// they will be drawn, top first.
+func (d *Deck) UpcomingOrder() []Card {
+	order := slices.Clone(d.cards)
+	slices.Reverse(order)
+	return order
+}
+
 func (d *Deck) reset() {
+	if d.stacked {
+		panic("game: stacked deck exhausted")
+	}
+	d.needsShuffle = false
+	d.running = 0
+	// The discards are gathered back in with the rest of the shoe.
+	d.discard = d.discard[:0]
 	d.cards = d.cards[:0]
 	for s := Clubs; s <= Spades; s++ {
 		for r := Ace; r <= King; r++ {
@@ -159,110 +381,1182 @@ func (d *Deck) reset() {
 		}
 	}
 	d.shuffle()
+	d.burn()
+	if d.OnShuffle != nil {
+		d.OnShuffle()
+	}
+}
+
+// burn discards BurnCards cards from the top of a freshly shuffled shoe.
+func (d *Deck) burn() {
+	n := min(max(d.BurnCards, 0), len(d.cards))
+	d.burned = slices.Clone(d.cards[len(d.cards)-n:])
+	slices.Reverse(d.burned)
+	d.discard = append(d.discard, d.burned...)
+	d.cards = d.cards[:len(d.cards)-n]
 }
 
+// Burned returns the cards burned after the last shuffle, in the order they
+// came off the shoe.
+func (d *Deck) Burned() []Card { return slices.Clone(d.burned) }
+
 func (d *Deck) shuffle() {
-	// Fisher-Yates shuffle
-	n := len(d.cards)
-	for i := n - 1; i > 0; i-- {
-		j := d.rng.Intn(i + 1)
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	if d.ShuffleFunc != nil {
+		d.ShuffleFunc(d.cards, d.rng)
+		return
+	}
+	FisherYates(d.cards, d.rng)
+}
+
+// FisherYates shuffles cards uniformly with rng. It is the shuffle a Deck
+// uses without a ShuffleFunc.
+func FisherYates(cards []Card, rng *rand.Rand) {
+	for i := len(cards) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		cards[i], cards[j] = cards[j], cards[i]
 	}
 }
 
 func (d *Deck) Draw() Card {
 	if len(d.cards) == 0 {
+		if d.StrictDeck {
+			panic("game: strict deck exhausted mid-round")
+		}
 		d.reset()
 	}
+	card, _ := d.TryDraw()
+	return card
+}
+
+// TryDraw draws the next card like Draw, but never reshuffles: it reports
+// false when the shoe is empty, leaving the caller to decide what to do.
+func (d *Deck) TryDraw() (Card, bool) {
+	if len(d.cards) == 0 {
+		return Card{}, false
+	}
 	card := d.cards[len(d.cards)-1]
 	d.cards = d.cards[:len(d.cards)-1]
-	return card
+	d.discard = append(d.discard, card)
+	d.running += hiLo(card)
+	if d.Penetration > 0 && float64(d.Size()-len(d.cards)) >= d.Penetration*float64(d.Size()) {
+		d.needsShuffle = true
+	}
+	return card, true
 }
 
+// NeedsShuffle reports whether the cut card has come out, or always under
+// ContinuousShuffle. The shoe is reshuffled at the start of the next round,
+// never mid-hand.
+func (d *Deck) NeedsShuffle() bool { return d.ContinuousShuffle || d.needsShuffle }
+
+// RunningCount returns the Hi-Lo count of the cards dealt since the last
+// shuffle: +1 for 2-6, -1 for tens and aces.
+func (d *Deck) RunningCount() int { return d.running }
+
+// TrueCount returns the running count divided by the number of decks left in
+// the shoe.
+func (d *Deck) TrueCount() float64 {
+	if len(d.cards) == 0 {
+		return float64(d.running)
+	}
+	return float64(d.running) / (float64(len(d.cards)) / 52)
+}
+
+func hiLo(c Card) int {
+	switch {
+		case c.Rank >= Two && c.Rank <= Six:
+			return 1
+		case c.Rank == Ace || c.Rank >= Ten:
+			return -1
+		default:
+			return 0
+	}
+}
+
+// Peek returns the card the next Draw will return without removing it. It
+// reports false when the shoe is empty.
+func (d *Deck) Peek() (Card, bool) {
+	if len(d.cards) == 0 {
+		return Card{}, false
+	}
+	return d.cards[len(d.cards)-1], true
+}
+
+// Remove takes the first matching card, nearest the top of the shoe, out of
+// the deck and reports whether one was found.
+func (d *Deck) Remove(c Card) bool {
+	for i := len(d.cards) - 1; i >= 0; i-- {
+		if d.cards[i].Equal(c) {
+			d.cards = slices.Delete(d.cards, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// ForceNext rigs the shoe so the next Draw returns c, moving the nearest
+// matching card to the top, or adding c if none is left. It is meant for
+// demos and tests; a game dealt from a rigged shoe is not fair.
+func (d *Deck) ForceNext(c Card) {
+	d.Remove(c)
+	d.cards = append(d.cards, c)
+}
+
+// Remaining returns the number of cards left in the shoe.
+func (d *Deck) Remaining() int { return len(d.cards) }
+
+// Discarded returns the number of cards out of the shoe since the last
+// shuffle: those drawn, in play or in the discard tray, and those burned.
+// Until a card is removed or forced in, it and Remaining add up to Size.
+func (d *Deck) Discarded() int { return len(d.discard) }
+
+// RankCounts returns how many cards of each rank are left in the deck,
+// indexed by Rank. Index 0 is unused.
+func (d *Deck) RankCounts() [14]int {
+	var counts [14]int
+	for _, c := range d.cards {
+		counts[c.Rank]++
+	}
+	return counts
+}
+
+// Size returns the number of cards in a full shoe.
+func (d *Deck) Size() int { return d.size }
+
+// Shoe returns the number of decks in the shoe. A stacked deck reports 1.
+func (d *Deck) Shoe() int { return d.shoe }
+
+const (
+	// DefaultBankroll is the starting bankroll of a new game.
+	DefaultBankroll = 1000
+	// DefaultBlackjackPayout pays a natural at 3:2.
+	DefaultBlackjackPayout = 1.5
+	// MaxShoe is the largest shoe a deck is built with, the casino maximum.
+	MaxShoe = 8
+)
+
+var (
+	ErrInvalidBet        = errors.New("bet must be positive")
+	ErrInsufficientFunds = errors.New("bet exceeds bankroll")
+	ErrRoundInProgress   = errors.New("round in progress")
+	ErrInvalidPayout     = errors.New("blackjack payout must be positive")
+	ErrNoBet             = errors.New("no bet placed")
+	ErrBetBelowMin       = errors.New("bet below table minimum")
+	ErrBetAboveMax       = errors.New("bet above table maximum")
+)
+
+// Game is a blackjack table. Its methods are safe for concurrent use, e.g.
+// by a UI and a bot: each holds the game's lock while it runs, including
+// while it calls the callbacks and Subscribe listeners, which therefore run
+// on the goroutine of the call that triggered them and must not call the
+// game's methods, though they may read its fields. The exported fields and
+// the Deck are not guarded; while other goroutines play, reach them through
+// Do.
 type Game struct {
-	Deck   *Deck
-	Player Hand
+	Deck *Deck
+	// PlayerHands holds every player hand in the round in playing order:
+	// seat by seat, with split hands following the hand they were split
+	// from.
+	PlayerHands []Hand
+	// Active is the index into PlayerHands of the hand being played.
+	Active int
 	Dealer Hand
 	State  State
-	Result string
+	// Result describes how the round ended; Outcome has the details.
+	Result  string
+	Outcome RoundOutcome
+	// Insured records that the player took insurance against a dealer
+	// blackjack this round.
+	Insured bool
+
+	// Bankroll is the money the player has off the table. Bets holds the
+	// wager of each seat in play, taken from the bankroll when placed, and
+	// Bet is the first seat's wager.
+	Bankroll int
+	Bet      int
+	Bets     []int
+	// BankrollHistory is the bankroll over the history, for plotting: the
+	// bankroll the history starts from, then the bankroll after each round
+	// recorded since.
+	BankrollHistory []int
+	// Rules are the table rules the round is played under.
+	Rules Rules
+
+	// OnStateChange, if set, is called synchronously each time State changes:
+	// on Deal, when the dealer's turn begins and once the round is settled.
+	OnStateChange func(old, new State)
+	// OnCardDealt, if set, is called synchronously for every card drawn from
+	// the deck, in deal order. to is "player" or "dealer".
+	OnCardDealt func(to string, c Card)
+	// OnHoleCardRevealed, if set, is called synchronously when the dealer's
+	// hole card is turned over: before the dealer's first draw, or as the
+	// round is settled if the dealer never draws. There is none to reveal
+	// under NoHoleCard.
+	OnHoleCardRevealed func(hole Card)
+	// Localizer, if set, writes Result and the hand results and labels of
+	// Outcome in place of English.
+	Localizer Localizer
+	// SidePerfectPairs is the Perfect Pairs side bet staked on each deal,
+	// taken from the bankroll when the cards come out. Zero places no bet.
+	SidePerfectPairs int
+	// Side213 is the 21+3 side bet staked on each deal, in the same way.
+	Side213 int
+	// DealerPolicy, if set, replaces the dealer's drawing rule, including
+	// DealerHitsSoft17: it is asked whether the dealer hits each time the
+	// dealer's hand is short of bust.
+	DealerPolicy func(dealer Hand) bool
+	// ManualDealer stops the round at DealerTurn once the player is done,
+	// with the hole card still face down, leaving the reveal to
+	// RevealHoleCard and the dealer's cards to RunDealer.
+	ManualDealer bool
+
+	// mu is held by every method; subMu guards subscribers, so a listener
+	// can unsubscribe while mu is held for its event.
+	mu            sync.Mutex
+	subMu         sync.Mutex
+	subscribers   []*subscriber
+	holeRevealed  bool
+	insuranceOpen bool
+	insuranceBet  int
+	betPlaced     bool
+	stats         Stats
+	undo          []undoStep
+	history       []RoundRecord
+	// actions and started track the round in progress for its record.
+	actions []Action
+	started time.Time
+	// sideBets are this round's side bets, settled on the deal.
+	sideBets []SideBetOutcome
+	recorder *Recorder
+	// headless skips what only a reader of the table needs, the round's
+	// history and result text, so simulations don't allocate every round.
+	headless bool
 }
 
 func NewGame(shoe int) *Game {
-	return &Game{
-		Deck:  NewDeck(shoe),
-		State: WaitingDeal,
+	return NewGameWithSeed(shoe, time.Now().UnixNano())
+}
+
+// NewGameWithSeed returns a game under DefaultRules with a shoe of the given
+// size, dealt from a deck seeded with seed, so the same sequence of actions
+// always produces the same cards.
+func NewGameWithSeed(shoe int, seed int64) *Game {
+	rules := DefaultRules()
+	rules.Decks = shoe
+	return NewGameWithRules(rules, seed)
+}
+
+// NewGameWithRules returns a game played under rules, dealt from a shoe of
+// rules.Decks seeded with seed.
+func NewGameWithRules(rules Rules, seed int64) *Game {
+	g := &Game{
+		Deck:     NewDeckWithSeed(rules.Decks, seed),
+		State:    WaitingDeal,
+		Bankroll: DefaultBankroll,
+		Rules:    rules,
+	}
+	g.BankrollHistory = []int{g.Bankroll}
+	g.applyDeckRules()
+	// The shoe was shuffled before the rules reached it, so burn now what
+	// its first reset would have.
+	g.Deck.burn()
+	return g
+}
+
+// applyDeckRules copies the shuffle rules onto the deck.
+func (g *Game) applyDeckRules() {
+	g.Deck.Penetration = g.Rules.Penetration
+	g.Deck.ContinuousShuffle = g.Rules.ContinuousShuffle
+	g.Deck.BurnCards = g.Rules.BurnCards
+}
+
+// PlaceBet stakes amount for the next round on a single seat. A bet already
+// placed for the round is returned to the bankroll first.
+func (g *Game) PlaceBet(amount int) error {
+	return g.PlaceBets(amount)
+}
+
+// PlaceBets stakes one bet per seat for the next round, playing as many
+// seats as there are bets. Bets already placed for the round are returned to
+// the bankroll first. Each bet must be within the table limits of Rules.
+func (g *Game) PlaceBets(amounts ...int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.placeBets(amounts...)
+}
+
+func (g *Game) placeBets(amounts ...int) error {
+	if g.State != WaitingDeal && g.State != RoundOver {
+		return ErrRoundInProgress
+	}
+	if g.Rules.BlackjackPayout <= 0 {
+		return ErrInvalidPayout
+	}
+	if len(amounts) == 0 {
+		return ErrInvalidBet
+	}
+	total := 0
+	for _, amount := range amounts {
+		if amount <= 0 {
+			return ErrInvalidBet
+		}
+		if limit := g.Rules.MinBet; limit > 0 && amount < limit {
+			return fmt.Errorf("%w: %d is under the %d minimum", ErrBetBelowMin, amount, limit)
+		}
+		if limit := g.Rules.MaxBet; limit > 0 && amount > limit {
+			return fmt.Errorf("%w: %d is over the %d maximum", ErrBetAboveMax, amount, limit)
+		}
+		total += amount
+	}
+	available := g.Bankroll
+	if g.betPlaced {
+		for _, bet := range g.Bets {
+			available += bet
+		}
 	}
+	if total > available {
+		return ErrInsufficientFunds
+	}
+	g.Bankroll = available - total
+	g.Bets = append(g.Bets[:0], amounts...)
+	g.Bet = amounts[0]
+	g.betPlaced = true
+	return nil
+}
+
+// ActiveHand returns the player hand currently being played, or nil before
+// the first deal.
+func (g *Game) ActiveHand() *Hand {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.activeHand()
+}
+
+func (g *Game) activeHand() *Hand {
+	if g.Active < 0 || g.Active >= len(g.PlayerHands) {
+		return nil
+	}
+	return &g.PlayerHands[g.Active]
 }
 
+// ActiveSeat returns the seat whose hand is being played.
+func (g *Game) ActiveSeat() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.activeSeat()
+}
+
+func (g *Game) activeSeat() int {
+	if hand := g.activeHand(); hand != nil {
+		return hand.Seat
+	}
+	return 0
+}
+
+// Deal starts a new round, dealing around the table one seat at a time. It
+// does nothing mid-round or until bets have been placed; DealChecked reports
+// why. A natural at every seat settles the round before Deal returns, except
+// against a dealer Ace, when it waits on the even-money offer first.
 func (g *Game) Deal() {
-	g.Player.Clear()
+	g.DealChecked()
+}
+
+// Rebet places the same bets as the last round, seat for seat, and deals. It
+// returns ErrNoBet before any bet has been placed, and the PlaceBets error,
+// such as ErrInsufficientFunds, if the bankroll can't cover them; a bet
+// placed but refused by the deal stays placed.
+func (g *Game) Rebet() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.Bets) == 0 {
+		return ErrNoBet
+	}
+	if err := g.placeBets(g.Bets...); err != nil {
+		return err
+	}
+	return g.dealChecked()
+}
+
+// DealChecked is Deal, returning ErrRoundInProgress or ErrNoBet when no
+// round can be dealt.
+func (g *Game) DealChecked() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.dealChecked()
+}
+
+func (g *Game) dealChecked() error {
+	if g.State != WaitingDeal && g.State != RoundOver {
+		return ErrRoundInProgress
+	}
+	if !g.betPlaced {
+		return ErrNoBet
+	}
+	if err := g.checkSideBets(); err != nil {
+		return err
+	}
+	g.betPlaced = false
+	g.applyDeckRules()
+	if g.Deck.NeedsShuffle() || (g.Deck.StrictDeck && !g.Deck.stacked && g.Deck.Remaining() < 2*len(g.Bets)+2) {
+		g.Deck.reset()
+	}
+	// The hands of the last round are cleared for reuse, as the dealer's is.
+	g.PlayerHands = slices.Grow(g.PlayerHands[:0], len(g.Bets))[:len(g.Bets)]
+	for seat, bet := range g.Bets {
+		hand := &g.PlayerHands[seat]
+		hand.Clear()
+		hand.Seat, hand.Bet = seat, bet
+	}
+	g.Active = 0
+	g.undo = g.undo[:0]
 	g.Dealer.Clear()
+	g.holeRevealed = false
 	g.Result = ""
-	g.State = PlayerTurn
+	g.Outcome = RoundOutcome{Hands: g.Outcome.Hands[:0]}
+	g.Insured = false
+	g.insuranceOpen = false
+	g.insuranceBet = 0
+	g.actions = g.actions[:0]
+	g.started = time.Now()
+	g.sideBets = nil
+	g.setState(PlayerTurn)
+
+	for i := 0; i < 2; i++ {
+		for seat := range g.PlayerHands {
+			g.draw(&g.PlayerHands[seat])
+		}
+		if i == 0 || !g.Rules.NoHoleCard {
+			g.draw(&g.Dealer)
+		}
+	}
 
-	g.Player.Add(g.Deck.Draw())
-	g.Dealer.Add(g.Deck.Draw())
-	g.Player.Add(g.Deck.Draw())
-	g.Dealer.Add(g.Deck.Draw())
+	g.settleSideBets()
+	g.emit(Event{Kind: EventDealt})
 
-	// Check for immediate blackjack
-	playerValue, _ := g.Player.Value()
-	dealerValue, _ := g.Dealer.Value()
-	if playerValue == 21 || dealerValue == 21 {
+	// Check for immediate blackjack. A natural stands at once, and the round
+	// is over if every seat has one, unless the dealer's Ace leaves even money
+	// to be offered first.
+	first := g.playableFrom(0)
+	ace := g.Dealer.Cards[0].Rank == Ace
+	if first == len(g.PlayerHands) && !ace {
 		g.finishRound()
+		return nil
 	}
+	if first < len(g.PlayerHands) {
+		g.Active = first
+	}
+	// Against an Ace the dealer only peeks once insurance has been taken or
+	// declined.
+	if ace {
+		g.insuranceOpen = true
+		return nil
+	}
+	g.peek()
+	return nil
 }
 
-func (g *Game) PlayerHit() {
-	if g.State != PlayerTurn {
+// playableFrom returns the index of the first hand from i on that still needs
+// playing, skipping finished ones, or len(g.PlayerHands) if there is none.
+func (g *Game) playableFrom(i int) int {
+	for i < len(g.PlayerHands) && g.handFinished(g.PlayerHands[i]) {
+		i++
+	}
+	return i
+}
+
+// handFinished reports whether a hand needs no decision: a natural, or a 21
+// under AutoStandOn21.
+func (g *Game) handFinished(hand Hand) bool {
+	value, _ := hand.Value()
+	return hand.IsBlackjack() || (value == 21 && g.Rules.AutoStandOn21)
+}
+
+// peek checks the hole card for a dealer natural when the rules and upcard
+// call for it, settling the round if found. It reports whether the round
+// ended.
+func (g *Game) peek() bool {
+	if !g.Rules.DealerPeek || g.Rules.NoHoleCard {
+		return false
+	}
+	up := g.Dealer.Cards[0].Rank
+	if up != Ace && up < Ten {
+		return false
+	}
+	if g.Dealer.IsBlackjack() {
+		g.finishRound()
+		return true
+	}
+	return false
+}
+
+// HoleCardHidden reports whether the dealer's second card must stay face down
+// in any display: it is hidden until it is revealed on the dealer's turn.
+// Under NoHoleCard there is no card to hide.
+func (g *Game) HoleCardHidden() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.holeCardHidden()
+}
+
+func (g *Game) holeCardHidden() bool {
+	if g.Rules.NoHoleCard {
+		return false
+	}
+	return g.State == PlayerTurn || (g.State == DealerTurn && !g.holeRevealed)
+}
+
+// RevealHoleCard turns the dealer's hole card over on the dealer's turn,
+// before any draw. Only a ManualDealer game needs to call it, to show the
+// card before RunDealer deals on; otherwise the dealer's turn reveals it.
+func (g *Game) RevealHoleCard() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.revealHoleCard()
+}
+
+func (g *Game) revealHoleCard() {
+	if g.State != DealerTurn {
+		return
+	}
+	g.revealHole()
+}
+
+func (g *Game) revealHole() {
+	if g.holeRevealed || g.Rules.NoHoleCard || len(g.Dealer.Cards) < 2 {
 		return
 	}
-	g.Player.Add(g.Deck.Draw())
-	playerValue, _ := g.Player.Value()
-	if playerValue > 21 {
+	g.holeRevealed = true
+	if g.OnHoleCardRevealed != nil {
+		g.OnHoleCardRevealed(g.Dealer.Cards[1])
+	}
+}
+
+// DealerUpcard returns the dealer's face-up card, or false before the first
+// deal.
+func (g *Game) DealerUpcard() (Card, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.dealerUpcard()
+}
+
+func (g *Game) dealerUpcard() (Card, bool) {
+	if len(g.Dealer.Cards) == 0 {
+		return Card{}, false
+	}
+	return g.Dealer.Cards[0], true
+}
+
+// VisibleDealer returns the dealer's cards a player at the table can see:
+// only the upcard while the hole card is hidden, the whole hand otherwise.
+func (g *Game) VisibleDealer() Hand {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.visibleDealer()
+}
+
+func (g *Game) visibleDealer() Hand {
+	cards := g.Dealer.Cards
+	if g.holeCardHidden() && len(cards) > 1 {
+		cards = cards[:1]
+	}
+	return Hand{Cards: slices.Clone(cards)}
+}
+
+// DealerValueString is ValueString for the dealer's cards that may be shown:
+// the upcard alone while the hole card is hidden, e.g. "soft 11" for an
+// Ace, then the whole hand. It is empty before the deal.
+func (g *Game) DealerValueString() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	visible := g.visibleDealer()
+	if len(visible.Cards) == 0 {
+		return ""
+	}
+	return visible.ValueString()
+}
+
+// OfferInsurance reports whether insurance can be taken: the dealer shows an
+// Ace and the player has not acted since the deal.
+func (g *Game) OfferInsurance() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.offerInsurance()
+}
+
+func (g *Game) offerInsurance() bool {
+	return g.State == PlayerTurn && g.insuranceOpen
+}
+
+// TakeInsurance insures every seat for half its bet, rounded down to a whole
+// chip. Insurance pays 2:1 if the dealer has blackjack. It is refused when
+// the bankroll can't cover it or the bets are too small to insure.
+func (g *Game) TakeInsurance() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.takeInsurance()
+}
+
+func (g *Game) takeInsurance() {
+	if !g.offerInsurance() {
+		return
+	}
+	stake := 0
+	for _, hand := range g.PlayerHands {
+		stake += hand.Bet / 2
+	}
+	if stake == 0 || g.Bankroll < stake {
+		return
+	}
+	g.Bankroll -= stake
+	g.insuranceBet = stake
+	g.Insured = true
+	g.closeInsurance()
+}
+
+// OfferEvenMoney reports whether even money can be taken: insurance is on
+// offer and the player holds a natural.
+func (g *Game) OfferEvenMoney() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.offerEvenMoney()
+}
+
+func (g *Game) offerEvenMoney() bool {
+	if !g.offerInsurance() {
+		return false
+	}
+	for _, hand := range g.PlayerHands {
+		if hand.IsBlackjack() {
+			return true
+		}
+	}
+	return false
+}
+
+// TakeEvenMoney settles the player's naturals at 1:1 instead of risking a
+// push against the dealer's Ace. The round ends at once if no other hand is
+// left to play.
+func (g *Game) TakeEvenMoney() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.offerEvenMoney() {
+		return
+	}
+	for i := range g.PlayerHands {
+		if g.PlayerHands[i].IsBlackjack() {
+			g.PlayerHands[i].EvenMoney = true
+		}
+	}
+	g.closeInsurance()
+}
+
+func (g *Game) DeclineInsurance() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.declineInsurance()
+}
+
+func (g *Game) declineInsurance() {
+	if !g.offerInsurance() {
+		return
+	}
+	g.closeInsurance()
+}
+
+// closeInsurance ends the insurance offer and lets the dealer peek, settling
+// the round if there is no hand left to play. It reports whether the round
+// ended.
+func (g *Game) closeInsurance() bool {
+	if !g.insuranceOpen {
+		return false
+	}
+	g.insuranceOpen = false
+	if g.peek() {
+		return true
+	}
+	if g.playableFrom(g.Active) == len(g.PlayerHands) {
 		g.finishRound()
+		return true
+	}
+	return false
+}
+
+func (g *Game) PlayerHit() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.playerHit()
+}
+
+func (g *Game) playerHit() {
+	if g.State != PlayerTurn || g.closeInsurance() {
+		return
+	}
+	hand := g.activeHand()
+	step := undoStep{active: g.Active, drew: true, needsShuffle: g.Deck.needsShuffle}
+	step.card = g.draw(hand)
+	g.undo = append(g.undo, step)
+	g.actions = append(g.actions, Hit)
+	g.emit(Event{Kind: EventHit, Hand: g.Active, Card: step.card})
+	if hand.IsBust() || g.isCharlie(*hand) || g.handFinished(*hand) {
+		g.nextHand()
 	}
 }
 
 func (g *Game) PlayerStand() {
-	if g.State != PlayerTurn {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.playerStand()
+}
+
+func (g *Game) playerStand() {
+	if g.State != PlayerTurn || g.closeInsurance() {
 		return
 	}
-	g.State = DealerTurn
-	for {
-		dealerValue, _ := g.Dealer.Value()
-		// Dealer hits on soft 17
-		// TODO: Implement rule variations if needed
-		if dealerValue < 17 || (dealerValue == 17 && g.isDealerSoft()) {
-			g.Dealer.Add(g.Deck.Draw())
-		} else {
-			break
+	g.undo = append(g.undo, undoStep{active: g.Active})
+	g.actions = append(g.actions, Stand)
+	g.emit(Event{Kind: EventStand, Hand: g.Active})
+	g.nextHand()
+}
+
+// CanDoubleDown reports whether the active hand may double down: it has two
+// cards, the bankroll covers the extra bet, and DoubleAfterSplit allows it if
+// the hand came from a split. A natural never doubles.
+func (g *Game) CanDoubleDown() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.canDoubleDown()
+}
+
+func (g *Game) canDoubleDown() bool {
+	hand := g.activeHand()
+	if g.State != PlayerTurn || hand == nil || g.handFinished(*hand) {
+		return false
+	}
+	return len(hand.Cards) == 2 && g.Bankroll >= hand.Bet && (!hand.FromSplit || g.Rules.DoubleAfterSplit)
+}
+
+// PlayerDoubleDown doubles the wager, draws exactly one card and ends the
+// active hand. Only allowed when CanDoubleDown.
+func (g *Game) PlayerDoubleDown() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.playerDoubleDown()
+}
+
+func (g *Game) playerDoubleDown() {
+	if g.State != PlayerTurn || !g.canDoubleDown() || g.closeInsurance() {
+		return
+	}
+	hand := g.activeHand()
+	g.Bankroll -= hand.Bet
+	g.undo = g.undo[:0]
+	g.actions = append(g.actions, Double)
+	hand.Bet *= 2
+	hand.Doubled = true
+	g.draw(hand)
+	g.nextHand()
+}
+
+// PlayerSplit splits a pair of equal rank into two hands, each receiving one
+// new card and carrying the original bet. The first of the two stays the
+// active hand, except that split aces are both finished at once under
+// SplitAcesOneCard, and either hand drawn to 21 stands by itself under
+// AutoStandOn21.
+func (g *Game) PlayerSplit() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.playerSplit()
+}
+
+func (g *Game) playerSplit() {
+	if g.State != PlayerTurn || !g.canSplit() || g.closeInsurance() {
+		return
+	}
+	hand := g.activeHand()
+	g.Bankroll -= hand.Bet
+	g.undo = g.undo[:0]
+	g.actions = append(g.actions, Split)
+	aces := hand.Cards[0].Rank == Ace
+	second := Hand{Cards: []Card{hand.Cards[1]}, Bet: hand.Bet, Seat: hand.Seat, FromSplit: true}
+	hand.Cards = hand.Cards[:1]
+	hand.FromSplit = true
+	g.draw(hand)
+	g.draw(&second)
+	g.PlayerHands = slices.Insert(g.PlayerHands, g.Active+1, second)
+	switch {
+		case aces && g.Rules.SplitAcesOneCard:
+			g.Active++
+			g.nextHand()
+		case g.handFinished(*g.activeHand()):
+			g.nextHand()
+	}
+}
+
+// PlayerSurrender gives up the active hand for half its bet. Only allowed
+// where the rules offer surrender, on a seat's unsplit opening hand before
+// any other action. A peek that finds a dealer blackjack ends the round
+// first; without one the surrender stands, but loses the whole bet to a
+// dealer natural.
+func (g *Game) PlayerSurrender() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.playerSurrender()
+}
+
+func (g *Game) playerSurrender() {
+	if !g.canSurrender() || g.closeInsurance() {
+		return
+	}
+	hand := g.activeHand()
+	hand.Surrendered = true
+	g.undo = g.undo[:0]
+	g.actions = append(g.actions, Surrender)
+	g.nextHand()
+}
+
+// CanSplit reports whether the active hand may split: it is a pair, the seat
+// has splits left under MaxSplits, and the bankroll covers the second bet. A
+// pair of aces made by a split only splits again under ResplitAces.
+func (g *Game) CanSplit() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.canSplit()
+}
+
+func (g *Game) canSplit() bool {
+	hand := g.activeHand()
+	if g.State != PlayerTurn || hand == nil {
+		return false
+	}
+	if hand.FromSplit && hand.Cards[0].Rank == Ace && !g.Rules.ResplitAces {
+		return false
+	}
+	return hand.CanSplit() && g.splitsLeft() > 0 && g.Bankroll >= hand.Bet
+}
+
+// CanSurrender reports whether the rules offer surrender on the active hand:
+// a seat's unsplit opening two cards, other than a natural. It doesn't look
+// at the hole card.
+func (g *Game) CanSurrender() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.canSurrender()
+}
+
+func (g *Game) canSurrender() bool {
+	hand := g.activeHand()
+	if g.State != PlayerTurn || hand == nil || g.handFinished(*hand) {
+		return false
+	}
+	return g.Rules.Surrender && len(hand.Cards) == 2 && g.seatHands(hand.Seat) == 1
+}
+
+// AvailableActions returns the plays open to the active hand, in Action
+// order, only DealNext once the round is over, and none otherwise. A natural
+// dealt against an Ace waits on the even-money offer with only Stand open,
+// which declines it as DeclineInsurance does; TakeEvenMoney is the other
+// choice.
+func (g *Game) AvailableActions() []Action {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.availableActions()
+}
+
+func (g *Game) availableActions() []Action {
+	if g.State == RoundOver {
+		return []Action{DealNext}
+	}
+	if g.State != PlayerTurn || g.activeHand() == nil {
+		return nil
+	}
+	if g.handFinished(*g.activeHand()) {
+		return []Action{Stand}
+	}
+	actions := []Action{Hit, Stand}
+	if g.canDoubleDown() {
+		actions = append(actions, Double)
+	}
+	if g.canSplit() {
+		actions = append(actions, Split)
+	}
+	if g.canSurrender() {
+		actions = append(actions, Surrender)
+	}
+	return actions
+}
+
+// SplitsLeft returns how many more times the active seat may split this
+// round under MaxSplits.
+func (g *Game) SplitsLeft() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.splitsLeft()
+}
+
+func (g *Game) splitsLeft() int {
+	if g.activeHand() == nil {
+		return 0
+	}
+	return max(g.Rules.MaxSplits-(g.seatHands(g.activeSeat())-1), 0)
+}
+
+// seatHands returns the number of hands seat is playing.
+func (g *Game) seatHands(seat int) int {
+	n := 0
+	for _, hand := range g.PlayerHands {
+		if hand.Seat == seat {
+			n++
 		}
 	}
+	return n
+}
+
+// nextHand moves play to the next hand to be played, or to the dealer once
+// every player hand has been played.
+func (g *Game) nextHand() {
+	if next := g.playableFrom(g.Active + 1); next < len(g.PlayerHands) {
+		g.Active = next
+		return
+	}
+	g.playDealer()
+}
+
+func (g *Game) playDealer() {
+	g.setState(DealerTurn)
+	if g.ManualDealer {
+		return
+	}
+	g.revealHole()
+	for g.dealerDraws() {
+		g.dealerHit()
+	}
 	g.finishRound()
 }
 
+// dealerDraws reports whether the dealer must take another card. The dealer
+// only draws if at least one player hand is still standing, and never once
+// bust.
+func (g *Game) dealerDraws() bool {
+	live := false
+	for _, hand := range g.PlayerHands {
+		if !hand.IsBust() && !hand.Surrendered && !hand.IsBlackjack() && !g.isCharlie(hand) {
+			live = true
+			break
+		}
+	}
+	if g.DealerPolicy != nil {
+		return live && !g.Dealer.IsBust() && g.DealerPolicy(g.Dealer)
+	}
+	dealerValue, _ := g.Dealer.Value()
+	return live && (dealerValue < 17 || (dealerValue == 17 && g.Rules.DealerHitsSoft17 && g.isDealerSoft()))
+}
+
+func (g *Game) dealerHit() Card {
+	return g.draw(&g.Dealer)
+}
+
+// draw deals the next card from the deck into a hand, the dealer's or a
+// player's, and reports it to OnCardDealt. Every card dealt goes through here.
+func (g *Game) draw(into *Hand) Card {
+	card := g.Deck.Draw()
+	into.Add(card)
+	to := "player"
+	if into == &g.Dealer {
+		to = "dealer"
+	}
+	g.cardDealt(to, card)
+	return card
+}
+
+func (g *Game) setState(s State) {
+	old := g.State
+	g.State = s
+	if old != s && g.OnStateChange != nil {
+		g.OnStateChange(old, s)
+	}
+}
+
+func (g *Game) cardDealt(to string, c Card) {
+	if g.OnCardDealt != nil {
+		g.OnCardDealt(to, c)
+	}
+}
+
+func (g *Game) isCharlie(hand Hand) bool {
+	return g.Rules.FiveCardCharlie && len(hand.Cards) >= 5 && !hand.IsBust()
+}
+
 func (g *Game) isDealerSoft() bool {
 	_, isSoft := g.Dealer.Value()
 	return isSoft
 }
 
 func (g *Game) finishRound() {
-	g.State = RoundOver
-	playerValue, _ := g.Player.Value()
-	dealerValue, _ := g.Dealer.Value()
+	g.revealHole()
+	// Without a hole card the dealer may not have drawn at all, e.g. when
+	// every hand busted, but a natural still needs the second card.
+	if g.Rules.NoHoleCard && len(g.Dealer.Cards) == 1 {
+		g.dealerHit()
+	}
+
+	// A recorded round keeps its outcomes, so only a headless game can reuse
+	// the last round's.
+	var hands []HandOutcome
+	if g.headless {
+		hands = slices.Grow(g.Outcome.Hands[:0], len(g.PlayerHands))[:len(g.PlayerHands)]
+	} else {
+		hands = make([]HandOutcome, len(g.PlayerHands))
+	}
+	g.Outcome = RoundOutcome{Hands: hands}
+	for i, hand := range g.PlayerHands {
+		outcome := CompareHands(hand, g.Dealer)
+		var key MessageKey
+		var args []int
+		if !g.headless {
+			key, args = handResult(hand, g.Dealer)
+		}
+		switch {
+			case hand.Surrendered && g.Dealer.IsBlackjack():
+				// No peek saved the surrender, so the natural takes the whole bet.
+				outcome = -1
+			case hand.Surrendered:
+				key, args, outcome = MsgSurrender, nil, -1
+			case hand.EvenMoney:
+				key, args, outcome = MsgEvenMoney, nil, 1
+			case g.isCharlie(hand):
+				key, args, outcome = MsgCharlie, nil, 1
+			case outcome == 0 && g.Rules.DealerWinsTies:
+				key, args, outcome = MsgDealerWinsTie, nil, -1
+		}
+		g.recordHand(hand, outcome)
+		payout := 0
+		switch {
+			case hand.Surrendered && !g.Dealer.IsBlackjack():
+				// Half the bet back, rounded down to a whole chip.
+				payout = hand.Bet / 2
+			case outcome > 0 && hand.IsBlackjack() && !hand.EvenMoney:
+				// Rounded down to a whole chip.
+				payout = hand.Bet + int(math.Floor(float64(hand.Bet)*g.Rules.BlackjackPayout))
+			case outcome > 0:
+				payout = 2 * hand.Bet
+			case outcome == 0:
+				payout = hand.Bet
+		}
+		g.Bankroll += payout
+		label, result := "", ""
+		if !g.headless {
+			label = g.handLabel(i)
+			result = message(g.localizer(), key, args)
+		}
+		g.Outcome.Hands[i] = HandOutcome{
+			Label:       label,
+			Result:      result,
+			Key:         key,
+			Args:        args,
+			Outcome:     outcome,
+			Surrendered: hand.Surrendered,
+			Net:         payout - hand.Bet,
+		}
+		g.Outcome.Net += payout - hand.Bet
+	}
+
+	if g.Insured {
+		g.Outcome.Insured = true
+		if g.Dealer.IsBlackjack() {
+			g.Bankroll += 3 * g.insuranceBet
+			g.Outcome.Net += 2 * g.insuranceBet
+			g.Outcome.InsuranceWon = true
+		} else {
+			g.Outcome.Net -= g.insuranceBet
+		}
+	}
+	g.Outcome.SideBets = g.sideBets
+	for _, side := range g.sideBets {
+		g.Outcome.Net += side.Net
+	}
+	if g.Bet > 0 {
+		g.Outcome.Units = float64(g.Outcome.Net) / float64(g.Bet)
+	}
+	if !g.headless {
+		g.Result = g.Outcome.Localize(g.localizer())
+		g.recordRound()
+	}
+	// The state changes only once the round is settled, so a listener sees
+	// its Result, Outcome, Bankroll and Stats.
+	g.setState(RoundOver)
+	if !g.headless {
+		g.emit(Event{Kind: EventRoundOver, Outcome: g.Outcome})
+	}
+}
 
+// handLabel names a hand when the round has more than one: "Hand 2" for split
+// hands at a single seat, "Seat 1" or "Seat 1 hand 2" at a multi-seat table.
+func (g *Game) handLabel(i int) string {
+	seat := g.PlayerHands[i].Seat
+	first := slices.IndexFunc(g.PlayerHands, func(h Hand) bool { return h.Seat == seat })
+	l := g.localizer()
 	switch {
-		case playerValue > 21:
-			g.Result = fmt.Sprintf("Player busts (%d). Dealer wins.", playerValue)
-		case dealerValue > 21:
-			g.Result = fmt.Sprintf("Dealer busts (%d). Player wins!", dealerValue)
+		case len(g.Bets) > 1 && g.seatHands(seat) > 1:
+			return l.Message(MsgSeatHand, seat+1, i-first+1)
+		case len(g.Bets) > 1:
+			return l.Message(MsgSeat, seat+1)
+		case len(g.PlayerHands) > 1:
+			return l.Message(MsgHand, i+1)
+		default:
+			return ""
+	}
+}
+
+// CompareHands resolves a player hand against the dealer's hand, returning
+// +1 for a player win, -1 for a dealer win and 0 for a push. A player bust
+// loses even if the dealer also busts, and a natural beats any other 21.
+func CompareHands(player, dealer Hand) int {
+	playerValue, _ := player.Value()
+	dealerValue, _ := dealer.Value()
+
+	switch {
+		case player.IsBust():
+			return -1
+		case player.IsBlackjack() && dealer.IsBlackjack():
+			return 0
+		case player.IsBlackjack():
+			return 1
+		case dealer.IsBlackjack():
+			return -1
+		case dealer.IsBust():
+			return 1
 		case playerValue > dealerValue:
-			g.Result = fmt.Sprintf("Player wins! (%d vs %d)", playerValue, dealerValue)
+			return 1
 		case playerValue < dealerValue:
-			g.Result = fmt.Sprintf("Dealer wins. (%d vs %d)", dealerValue, playerValue)
+			return -1
 		default:
-			g.Result = fmt.Sprintf("Push. (%d vs %d)", playerValue, dealerValue)
+			return 0
 	}
 }
 
+// handResult picks the message describing a single player hand's
+// CompareHands result against the dealer, with its arguments.
+func handResult(hand, dealer Hand) (MessageKey, []int) {
+	playerValue, _ := hand.Value()
+	dealerValue, _ := dealer.Value()
+	outcome := CompareHands(hand, dealer)
+
+	switch {
+		case outcome == 0 && hand.IsBlackjack():
+			return MsgPushBlackjack, nil
+		case outcome == 0:
+			return MsgPush, []int{playerValue, dealerValue}
+		case outcome > 0 && hand.IsBlackjack():
+			return MsgPlayerBlackjack, nil
+		case outcome > 0 && dealer.IsBust():
+			return MsgDealerBusts, []int{dealerValue}
+		case outcome > 0:
+			return MsgPlayerWins, []int{playerValue, dealerValue}
+		case hand.IsBust():
+			return MsgPlayerBusts, []int{playerValue}
+		case dealer.IsBlackjack():
+			return MsgDealerBlackjack, nil
+		default:
+			return MsgDealerWins, []int{dealerValue, playerValue}
+	}
+}
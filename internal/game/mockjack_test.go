@@ -0,0 +1,1186 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// spades returns a spade of each rank, in order.
+func spades(ranks ...Rank) []Card {
+	cards := make([]Card, len(ranks))
+	for i, r := range ranks {
+		cards[i] = Card{Suit: Spades, Rank: r}
+	}
+	return cards
+}
+
+// stackedGame returns a game under rules whose deck deals exactly ranks, in
+// order: player, dealer, player, dealer for a single seat, then the draws.
+func stackedGame(rules Rules, ranks ...Rank) *Game {
+	g := NewGameWithRules(rules, 1)
+	g.Deck = NewStackedDeck(spades(ranks...))
+	return g
+}
+
+func TestPlaceBetTableLimits(t *testing.T) {
+	tests := []struct {
+		bet  int
+		want error
+	}{
+		{4, ErrBetBelowMin},
+		{5, nil},
+		{100, nil},
+		{500, nil},
+		{501, ErrBetAboveMax},
+	}
+	for _, tt := range tests {
+		g := NewGame(1)
+		err := g.PlaceBet(tt.bet)
+		if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("PlaceBet(%d) = %v, want %v", tt.bet, err, tt.want)
+		}
+		if err != nil && g.Bankroll != DefaultBankroll {
+			t.Errorf("PlaceBet(%d) refused but took %d from the bankroll", tt.bet, DefaultBankroll-g.Bankroll)
+		}
+	}
+}
+
+func TestPlaceBetsTableLimitsPerSeat(t *testing.T) {
+	g := NewGame(1)
+	g.Rules.MaxBet = 50
+	if err := g.PlaceBets(50, 60); !errors.Is(err, ErrBetAboveMax) {
+		t.Fatalf("PlaceBets(50, 60) = %v, want %v", err, ErrBetAboveMax)
+	}
+	g.Rules.MinBet, g.Rules.MaxBet = 0, 0
+	if err := g.PlaceBet(1000); err != nil {
+		t.Fatalf("PlaceBet(1000) without limits = %v", err)
+	}
+}
+
+func TestPlayerSurrender(t *testing.T) {
+	// Player 10,6 against the dealer's 9,7.
+	g := stackedGame(DefaultRules(), Ten, Nine, Six, Seven)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSurrender()
+	if g.State != RoundOver || !g.PlayerHands[0].Surrendered {
+		t.Fatalf("surrender refused: state %v", g.State)
+	}
+	if g.Bankroll != DefaultBankroll-5 {
+		t.Errorf("bankroll after surrender = %d, want %d", g.Bankroll, DefaultBankroll-5)
+	}
+}
+
+func TestPlayerSurrenderAfterHit(t *testing.T) {
+	g := stackedGame(DefaultRules(), Ten, Nine, Two, Seven, Two)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerHit()
+	g.PlayerSurrender()
+	if g.State != PlayerTurn || g.PlayerHands[0].Surrendered {
+		t.Errorf("surrender accepted after a hit")
+	}
+}
+
+func TestPlayerSurrenderAgainstDealerNatural(t *testing.T) {
+	rules := DefaultRules()
+	rules.DealerPeek = false
+	// Player 10,6 against the dealer's 10,A, which nobody has peeked at.
+	g := stackedGame(rules, Ten, Ten, Six, Ace)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSurrender()
+	if g.State != RoundOver || !g.PlayerHands[0].Surrendered {
+		t.Fatalf("surrender refused, giving away the hole card: state %v", g.State)
+	}
+	if g.Bankroll != DefaultBankroll-10 {
+		t.Errorf("bankroll = %d, want the whole bet lost: %d", g.Bankroll, DefaultBankroll-10)
+	}
+}
+
+func TestUndoAfterSurrender(t *testing.T) {
+	// Seat 1 stands on 10,7 and seat 2 surrenders 10,6, with seat 3's 10,5
+	// still to play against the dealer's 9,8.
+	g := stackedGame(DefaultRules(), Ten, Ten, Ten, Nine, Seven, Six, Five, Eight)
+	if err := g.PlaceBets(10, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	g.Deal()
+	g.PlayerStand()
+	g.PlayerSurrender()
+	if g.Undo() {
+		t.Error("Undo took back the play before a surrender")
+	}
+	if g.ActiveSeat() != 2 || !g.PlayerHands[1].Surrendered {
+		t.Errorf("after Undo: active seat %d, seat 2 surrendered %v", g.ActiveSeat(), g.PlayerHands[1].Surrendered)
+	}
+	if got, want := g.actions, []Action{Stand, Surrender}; !slices.Equal(got, want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+}
+
+func TestBlackjackPayoutRoundsDown(t *testing.T) {
+	tests := []struct {
+		payout float64
+		bet    int
+		want   int
+	}{
+		{1.5, 10, 15},
+		{1.5, 5, 7},
+		{1.2, 10, 12},
+		{1.2, 6, 7},
+	}
+	for _, tt := range tests {
+		rules := DefaultRules()
+		rules.BlackjackPayout = tt.payout
+		// Player A,K against the dealer's 9,7.
+		g := stackedGame(rules, Ace, Nine, King, Seven)
+		g.PlaceBet(tt.bet)
+		g.Deal()
+		if g.State != RoundOver {
+			t.Fatalf("natural left the round in %v", g.State)
+		}
+		if won := g.Bankroll - DefaultBankroll; won != tt.want {
+			t.Errorf("%v on a %d natural won %d, want %d", tt.payout, tt.bet, won, tt.want)
+		}
+	}
+}
+
+func TestTakeInsuranceTooSmallToInsure(t *testing.T) {
+	rules := DefaultRules()
+	rules.MinBet = 0
+	// Player 10,7 against the dealer's A,9.
+	g := stackedGame(rules, Ten, Ace, Seven, Nine)
+	g.PlaceBet(1)
+	g.Deal()
+	g.TakeInsurance()
+	if g.Insured || !g.OfferInsurance() {
+		t.Errorf("a 1 bet was insured for nothing")
+	}
+}
+
+func TestRefusedSplitLeavesInsuranceOpen(t *testing.T) {
+	// Player 10,7, not a pair, against the dealer's A,K.
+	g := stackedGame(DefaultRules(), Ten, Ace, Seven, King)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	if !g.OfferInsurance() || g.State != PlayerTurn {
+		t.Errorf("refused split: insurance offered %v, state %v; want the offer still open", g.OfferInsurance(), g.State)
+	}
+}
+
+func TestRefusedDoubleLeavesInsuranceOpen(t *testing.T) {
+	// Player 10,7 against the dealer's A,K, with too little left to double.
+	g := stackedGame(DefaultRules(), Ten, Ace, Seven, King)
+	g.PlaceBet(10)
+	g.Deal()
+	g.Bankroll = 5
+	g.PlayerDoubleDown()
+	if !g.OfferInsurance() || g.State != PlayerTurn {
+		t.Errorf("refused double: insurance offered %v, state %v; want the offer still open", g.OfferInsurance(), g.State)
+	}
+}
+
+func TestDealerSoft17(t *testing.T) {
+	for _, hitsSoft17 := range []bool{true, false} {
+		rules := DefaultRules()
+		rules.DealerHitsSoft17 = hitsSoft17
+		// Player 10,10 against the dealer's 6,A, with a 2 to draw.
+		g := stackedGame(rules, Ten, Six, Ten, Ace, Two)
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerStand()
+		drew := len(g.Dealer.Cards) == 3
+		if drew != hitsSoft17 {
+			t.Errorf("DealerHitsSoft17 %v: dealer drew to %v", hitsSoft17, g.Dealer)
+		}
+	}
+}
+
+func TestDeckRemaining(t *testing.T) {
+	d := NewDeckWithSeed(1, 1)
+	if d.Remaining() != 52 || d.Size() != 52 {
+		t.Fatalf("new deck: Remaining %d, Size %d, want 52 and 52", d.Remaining(), d.Size())
+	}
+	for i := 1; i <= 52; i++ {
+		d.Draw()
+		if d.Remaining() != 52-i {
+			t.Fatalf("after %d draws Remaining = %d, want %d", i, d.Remaining(), 52-i)
+		}
+	}
+	d.Draw()
+	if d.Remaining() != 51 {
+		t.Errorf("after the reshuffle Remaining = %d, want 51", d.Remaining())
+	}
+}
+
+func TestDeckPeek(t *testing.T) {
+	d := NewDeckWithSeed(1, 3)
+	card, ok := d.Peek()
+	if !ok || d.Remaining() != 52 {
+		t.Fatalf("Peek = %v, %v with %d remaining", card, ok, d.Remaining())
+	}
+	if drawn := d.Draw(); drawn != card {
+		t.Errorf("Draw after Peek = %v, want %v", drawn, card)
+	}
+	if _, ok := NewStackedDeck(nil).Peek(); ok {
+		t.Errorf("Peek on an empty deck reported a card")
+	}
+}
+
+func TestHandIsBust(t *testing.T) {
+	if hand := (Hand{Cards: spades(King, Queen, Five)}); !hand.IsBust() {
+		t.Errorf("%v is not bust", hand)
+	}
+	if hand := (Hand{Cards: spades(Ace, Five, Five)}); hand.IsBust() {
+		t.Errorf("soft 21 %v is bust", hand)
+	}
+}
+
+func TestHandCanSplit(t *testing.T) {
+	tests := []struct {
+		ranks []Rank
+		want  bool
+	}{
+		{[]Rank{Eight, Eight}, true},
+		{[]Rank{King, Queen}, false},
+		{[]Rank{Eight, Eight, Eight}, false},
+	}
+	for _, tt := range tests {
+		hand := Hand{Cards: spades(tt.ranks...)}
+		if got := hand.CanSplit(); got != tt.want {
+			t.Errorf("%v CanSplit = %v, want %v", hand, got, tt.want)
+		}
+	}
+}
+
+func TestCompareHands(t *testing.T) {
+	tests := []struct {
+		name           string
+		player, dealer []Rank
+		want           int
+	}{
+		{"both bust", []Rank{Ten, Six, Nine}, []Rank{Ten, Six, Eight}, -1},
+		{"dealer busts", []Rank{Ten, Two}, []Rank{Ten, Six, Eight}, 1},
+		{"push", []Rank{Ten, Eight}, []Rank{Nine, Nine}, 0},
+		{"natural beats 21", []Rank{Ace, King}, []Rank{Seven, Seven, Seven}, 1},
+		{"21 loses to natural", []Rank{Seven, Seven, Seven}, []Rank{Ace, King}, -1},
+		{"both natural", []Rank{Ace, King}, []Rank{Queen, Ace}, 0},
+		{"higher", []Rank{Ten, Nine}, []Rank{Ten, Eight}, 1},
+		{"lower", []Rank{Ten, Seven}, []Rank{Ten, Eight}, -1},
+	}
+	for _, tt := range tests {
+		player, dealer := Hand{Cards: spades(tt.player...)}, Hand{Cards: spades(tt.dealer...)}
+		if got := CompareHands(player, dealer); got != tt.want {
+			t.Errorf("%s: CompareHands(%v, %v) = %d, want %d", tt.name, player, dealer, got, tt.want)
+		}
+	}
+}
+
+func TestDeckRemove(t *testing.T) {
+	d := NewDeckWithSeed(1, 3)
+	ace := Card{Suit: Spades, Rank: Ace}
+	if !d.Remove(ace) || d.Remaining() != 51 {
+		t.Fatalf("Remove(%v) left %d cards, want 51", ace, d.Remaining())
+	}
+	if d.Remove(ace) {
+		t.Errorf("Remove(%v) found a second one in a single deck", ace)
+	}
+}
+
+func TestDeckForceNext(t *testing.T) {
+	aceOfHearts := Card{Hearts, Ace}
+	d := NewDeckWithSeed(1, 3)
+	d.ForceNext(aceOfHearts)
+	if d.Remaining() != 52 {
+		t.Errorf("ForceNext of a card in the deck left %d cards, want 52", d.Remaining())
+	}
+	if c := d.Draw(); c != aceOfHearts {
+		t.Errorf("Draw after ForceNext(%v) = %v", aceOfHearts, c)
+	}
+
+	// The only ace of hearts is gone, so forcing it again adds one.
+	d.ForceNext(aceOfHearts)
+	if d.Remaining() != 52 {
+		t.Errorf("ForceNext of a missing card left %d cards, want 52", d.Remaining())
+	}
+	if c := d.Draw(); c != aceOfHearts {
+		t.Errorf("Draw after ForceNext(%v) = %v", aceOfHearts, c)
+	}
+}
+
+func TestDeckHiLoCount(t *testing.T) {
+	d := NewStackedDeck(spades(Two, Six, Seven, Nine, Ten, King, Ace, Five))
+	want := []int{1, 2, 2, 2, 1, 0, -1, 0}
+	for i, count := range want {
+		card := d.Draw()
+		if d.RunningCount() != count {
+			t.Fatalf("after %v (draw %d) RunningCount = %d, want %d", card, i+1, d.RunningCount(), count)
+		}
+	}
+
+	d = NewDeckWithSeed(2, 1)
+	for d.RunningCount() < 4 {
+		d.Draw()
+	}
+	decks := float64(d.Remaining()) / 52
+	if got, want := d.TrueCount(), float64(d.RunningCount())/decks; got != want {
+		t.Errorf("TrueCount = %v, want %v", got, want)
+	}
+	for d.Remaining() > 0 {
+		d.Draw()
+	}
+	card := d.Draw()
+	if got, want := d.RunningCount(), hiLo(card); got != want {
+		t.Errorf("RunningCount after the reshuffle = %d, want %d", got, want)
+	}
+}
+
+func TestTwoSeatRound(t *testing.T) {
+	// Seat 1 gets 10,6 and busts on a 9; seat 2 stands on 10,9 against the
+	// dealer's 10,8.
+	g := stackedGame(DefaultRules(), Ten, Ten, Ten, Six, Nine, Eight, Nine)
+	if err := g.PlaceBets(10, 20); err != nil {
+		t.Fatal(err)
+	}
+	g.Deal()
+	if g.ActiveSeat() != 0 {
+		t.Fatalf("play starts at seat %d", g.ActiveSeat())
+	}
+	g.PlayerHit()
+	if g.ActiveSeat() != 1 || g.State != PlayerTurn {
+		t.Fatalf("after seat 1 busts: seat %d, state %v", g.ActiveSeat(), g.State)
+	}
+	g.PlayerStand()
+	if g.State != RoundOver {
+		t.Fatalf("round not over: %v", g.State)
+	}
+	if got := []int{g.Outcome.Hands[0].Net, g.Outcome.Hands[1].Net}; got[0] != -10 || got[1] != 20 {
+		t.Errorf("seat nets = %v, want [-10 20]", got)
+	}
+	if g.Bankroll != DefaultBankroll+10 {
+		t.Errorf("bankroll = %d, want %d", g.Bankroll, DefaultBankroll+10)
+	}
+}
+
+func TestSplitAcesOneCard(t *testing.T) {
+	// A,A against the dealer's 9,8; the split aces draw a K and a 5.
+	g := stackedGame(DefaultRules(), Ace, Nine, Ace, Eight, King, Five, Two)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	g.PlayerHit()
+	if g.State != RoundOver {
+		t.Fatalf("split aces left the round in %v", g.State)
+	}
+	for _, hand := range g.PlayerHands {
+		if len(hand.Cards) != 2 {
+			t.Errorf("split ace hand %v got more than one card", hand)
+		}
+	}
+	if net := g.Outcome.Hands[0].Net; net != 10 {
+		t.Errorf("split A,K won %d, want 10: it is 21, not a natural", net)
+	}
+}
+
+func TestMaxSplits(t *testing.T) {
+	rules := DefaultRules()
+	rules.MaxSplits = 2
+	g := stackedGame(rules, Eight, Nine, Eight, Nine, Eight, Eight, Eight, Eight, Two, Two, Two)
+	g.PlaceBet(10)
+	g.Deal()
+	for want := 2; want > 0; want-- {
+		if g.SplitsLeft() != want {
+			t.Fatalf("SplitsLeft = %d, want %d", g.SplitsLeft(), want)
+		}
+		g.PlayerSplit()
+	}
+	if g.SplitsLeft() != 0 {
+		t.Fatalf("SplitsLeft at the cap = %d", g.SplitsLeft())
+	}
+	g.PlayerSplit()
+	if len(g.PlayerHands) != 3 {
+		t.Errorf("split past MaxSplits: %d hands", len(g.PlayerHands))
+	}
+}
+
+func TestDoubleAfterSplit(t *testing.T) {
+	for _, das := range []bool{true, false} {
+		rules := DefaultRules()
+		rules.DoubleAfterSplit = das
+		// 4,4 against the dealer's 9,8; the first split hand draws a 7.
+		g := stackedGame(rules, Four, Nine, Four, Eight, Seven, Six, Ten, Ten, Ten)
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerSplit()
+		g.PlayerDoubleDown()
+		if doubled := g.PlayerHands[0].Doubled; doubled != das {
+			t.Errorf("DoubleAfterSplit %v: split hand doubled = %v", das, doubled)
+		}
+	}
+
+	rules := DefaultRules()
+	rules.DoubleAfterSplit = false
+	g := stackedGame(rules, Four, Nine, Four, Eight, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerDoubleDown()
+	if !g.PlayerHands[0].Doubled {
+		t.Errorf("DoubleAfterSplit false refused a double on an unsplit 4,4")
+	}
+}
+
+func TestResplitAces(t *testing.T) {
+	for _, resplit := range []bool{true, false} {
+		rules := DefaultRules()
+		rules.SplitAcesOneCard = false
+		rules.ResplitAces = resplit
+		// A,A against the dealer's 10,7; the first split ace draws another.
+		g := stackedGame(rules, Ace, Ten, Ace, Seven, Ace, Five, Nine, Nine)
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerSplit()
+		if g.CanSplit() != resplit {
+			t.Errorf("ResplitAces %v: CanSplit() = %v on A,A made by a split", resplit, !resplit)
+		}
+		g.PlayerSplit()
+		want, bankroll := 2, DefaultBankroll-20
+		if resplit {
+			want, bankroll = 3, DefaultBankroll-30
+		}
+		if len(g.PlayerHands) != want || g.Bankroll != bankroll {
+			t.Errorf("ResplitAces %v: %d hands and bankroll %d after resplitting, want %d and %d", resplit, len(g.PlayerHands), g.Bankroll, want, bankroll)
+		}
+	}
+}
+
+func TestContinuousShuffle(t *testing.T) {
+	rules := DefaultRules()
+	rules.ContinuousShuffle = true
+	rules.Penetration = 0.01
+	g := NewGameWithRules(rules, 3)
+	for i := 0; i < 5; i++ {
+		g.PlaceBet(10)
+		g.Deal()
+		count := 0
+		for _, hand := range append([]Hand{g.Dealer}, g.PlayerHands...) {
+			for _, c := range hand.Cards {
+				count += hiLo(c)
+			}
+		}
+		if g.Deck.Remaining() != g.Deck.Size()-4 || g.Deck.RunningCount() != count {
+			t.Fatalf("round %d: %d left, count %d, want a fresh shoe and count %d",
+				i, g.Deck.Remaining(), g.Deck.RunningCount(), count)
+		}
+		g.PlayPlayerWith(BasicStrategyPlayer{})
+	}
+}
+
+func TestBurnCards(t *testing.T) {
+	rules := DefaultRules()
+	rules.Decks = 1
+	rules.BurnCards = 1
+	g := NewGameWithRules(rules, 1)
+	if g.Deck.Remaining() != 51 || len(g.Deck.Burned()) != 1 {
+		t.Fatalf("first shoe: %d left, %v burned", g.Deck.Remaining(), g.Deck.Burned())
+	}
+	g.Deck.reset()
+	if g.Deck.Remaining() != g.Deck.Size()-1 || len(g.Deck.Burned()) != 1 {
+		t.Errorf("after a reset: %d left, %v burned", g.Deck.Remaining(), g.Deck.Burned())
+	}
+}
+
+func TestDeckRankCounts(t *testing.T) {
+	var cards []Card
+	for r := Ace; r <= King; r++ {
+		for s := Clubs; s <= Spades; s++ {
+			cards = append(cards, Card{Suit: s, Rank: r})
+		}
+	}
+	d := NewStackedDeck(cards)
+	for i := 0; i < 4; i++ {
+		d.Draw()
+	}
+	counts := d.RankCounts()
+	if counts[Ace] != 0 {
+		t.Errorf("%d aces left after drawing all four", counts[Ace])
+	}
+	for r := Two; r <= King; r++ {
+		if counts[r] != 4 {
+			t.Errorf("%d of rank %d left, want 4", counts[r], r)
+		}
+	}
+}
+
+func TestNewDeckClampsShoe(t *testing.T) {
+	d := NewDeck(1000)
+	if d.Shoe() != MaxShoe || d.Size() != 52*MaxShoe || d.Remaining() != 52*MaxShoe {
+		t.Errorf("NewDeck(1000): Shoe %d, Size %d, Remaining %d, want %d decks", d.Shoe(), d.Size(), d.Remaining(), MaxShoe)
+	}
+	if d := NewDeck(0); d.Shoe() != 1 {
+		t.Errorf("NewDeck(0).Shoe() = %d, want 1", d.Shoe())
+	}
+}
+
+func TestDealGuard(t *testing.T) {
+	g := stackedGame(DefaultRules(), Ten, Nine, Six, Eight)
+	if err := g.DealChecked(); err != ErrNoBet {
+		t.Fatalf("DealChecked without a bet = %v, want %v", err, ErrNoBet)
+	}
+	g.PlaceBet(10)
+	g.Deal()
+	before := g.PlayerHands[0].String()
+	if err := g.DealChecked(); err != ErrRoundInProgress {
+		t.Errorf("DealChecked mid-hand = %v, want %v", err, ErrRoundInProgress)
+	}
+	g.Deal()
+	if g.State != PlayerTurn || g.PlayerHands[0].String() != before {
+		t.Errorf("Deal mid-hand changed the round: %v, %v", g.State, g.PlayerHands[0])
+	}
+}
+
+func TestHandTotalsMultipleAces(t *testing.T) {
+	tests := []struct {
+		ranks  []Rank
+		best   int
+		isSoft bool
+	}{
+		{[]Rank{Ace, Ace}, 12, true},
+		{[]Rank{Ace, Ace, Nine}, 21, true},
+		{[]Rank{Ace, Ace, Nine, Nine}, 20, false},
+		{[]Rank{Ace, Six, Ace}, 18, true},
+	}
+	for _, tt := range tests {
+		hand := Hand{Cards: spades(tt.ranks...)}
+		best, isSoft := hand.Value()
+		if best != tt.best || isSoft != tt.isSoft {
+			t.Errorf("%v Value() = %d, %v, want %d, %v", hand, best, isSoft, tt.best, tt.isSoft)
+		}
+	}
+}
+
+func TestAutoStandOn21(t *testing.T) {
+	rules := DefaultRules()
+	// Player 5,6 hits a 10 to 21 against the dealer's 9,8.
+	g := stackedGame(rules, Five, Nine, Six, Eight, Ten)
+	g.ManualDealer = true
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerHit()
+	if g.State != DealerTurn {
+		t.Errorf("hitting to 21 left the game in %v, want %v", g.State, DealerTurn)
+	}
+}
+
+func TestAutoStandOn21AfterSplit(t *testing.T) {
+	// 10,10 against the dealer's 9,8; the split hands draw an A and a 3.
+	g := stackedGame(DefaultRules(), Ten, Nine, Ten, Eight, Ace, Three, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	if g.Active != 1 {
+		t.Fatalf("split 10,A is still the active hand")
+	}
+	g.PlayerStand()
+	if g.State != RoundOver || len(g.PlayerHands[0].Cards) != 2 {
+		t.Errorf("split 21 was played on: %v, state %v", g.PlayerHands[0], g.State)
+	}
+
+	// The second hand is skipped in the same way.
+	g = stackedGame(DefaultRules(), Ten, Nine, Ten, Eight, Three, Ace, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	g.PlayerStand()
+	if g.State != RoundOver {
+		t.Errorf("split 10,A waited for a decision: state %v", g.State)
+	}
+}
+
+func TestVisibleDealerHidesHoleCard(t *testing.T) {
+	g := stackedGame(DefaultRules(), Ten, Nine, Six, King, Two)
+	g.PlaceBet(10)
+	g.Deal()
+	visible := g.VisibleDealer()
+	if len(visible.Cards) != 1 || visible.Cards[0].Rank != Nine {
+		t.Fatalf("VisibleDealer during the player's turn = %v, want the 9 alone", visible)
+	}
+	if up, ok := g.DealerUpcard(); !ok || up.Rank != Nine {
+		t.Errorf("DealerUpcard = %v, %v", up, ok)
+	}
+	g.PlayerStand()
+	if visible := g.VisibleDealer(); len(visible.Cards) != len(g.Dealer.Cards) {
+		t.Errorf("VisibleDealer after the round = %v, want %v", visible, g.Dealer)
+	}
+}
+
+func TestDealerPolicyNeverHit(t *testing.T) {
+	// Player 10,9 against the dealer's 10,2, who would normally draw the 9.
+	g := stackedGame(DefaultRules(), Ten, Ten, Nine, Two, Nine)
+	g.DealerPolicy = func(Hand) bool { return false }
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerStand()
+	if len(g.Dealer.Cards) != 2 || g.Bankroll != DefaultBankroll+10 {
+		t.Errorf("never-hit dealer drew to %v; bankroll %d", g.Dealer, g.Bankroll)
+	}
+
+	g = stackedGame(DefaultRules(), Ten, Ten, Nine, Two, Nine)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerStand()
+	if len(g.Dealer.Cards) != 3 {
+		t.Errorf("default dealer stood on %v", g.Dealer)
+	}
+}
+
+func TestOnCardDealtOncePerCard(t *testing.T) {
+	// 8,8 against the dealer's 6,10: split, double the first hand (8,3) to 20,
+	// hit the second (8,2) to 17 and stand, then the dealer draws to bust.
+	g := stackedGame(DefaultRules(), Eight, Six, Eight, Ten, Three, Two, Nine, Seven, Eight)
+	var dealt []string
+	g.OnCardDealt = func(to string, c Card) { dealt = append(dealt, to+" "+c.String()) }
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	g.PlayerDoubleDown()
+	g.PlayerHit()
+	g.PlayerStand()
+	if g.State != RoundOver {
+		t.Fatalf("round not over: %v", g.State)
+	}
+	want := []string{"player 8♠", "dealer 6♠", "player 8♠", "dealer 10♠", "player 3♠", "player 2♠", "player 9♠", "player 7♠", "dealer 8♠"}
+	if len(dealt) != len(want) {
+		t.Fatalf("OnCardDealt calls = %v, want %v", dealt, want)
+	}
+	for i := range want {
+		if dealt[i] != want[i] {
+			t.Fatalf("OnCardDealt calls = %v, want %v", dealt, want)
+		}
+	}
+}
+
+func TestRevealHoleCardBeforeDealerDraws(t *testing.T) {
+	// Player 10,9 against the dealer's 10,2, who draws the 9.
+	g := stackedGame(DefaultRules(), Ten, Ten, Nine, Two, Nine)
+	g.ManualDealer = true
+	var events []string
+	g.OnCardDealt = func(to string, c Card) { events = append(events, to+" "+c.String()) }
+	g.OnHoleCardRevealed = func(c Card) { events = append(events, "reveal "+c.String()) }
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerStand()
+	if g.State != DealerTurn || !g.HoleCardHidden() {
+		t.Fatalf("after stand: state %v, hole hidden %v", g.State, g.HoleCardHidden())
+	}
+	g.RevealHoleCard()
+	if g.HoleCardHidden() || len(g.Dealer.Cards) != 2 {
+		t.Fatalf("after RevealHoleCard: hole hidden %v, dealer %v", g.HoleCardHidden(), g.Dealer)
+	}
+	if err := g.RunDealer(context.Background(), 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"player 10♠", "dealer 10♠", "player 9♠", "dealer 2♠", "reveal 2♠", "dealer 9♠"}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestHoleCardRevealedOnce(t *testing.T) {
+	for _, manual := range []bool{false, true} {
+		g := stackedGame(DefaultRules(), Ten, Ten, Nine, Two, Nine)
+		g.ManualDealer = manual
+		reveals := 0
+		g.OnHoleCardRevealed = func(Card) { reveals++ }
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerStand()
+		if err := g.RunDealer(context.Background(), 0, nil); err != nil {
+			t.Fatal(err)
+		}
+		if g.State != RoundOver || reveals != 1 {
+			t.Errorf("ManualDealer %v: state %v, %d reveals, want 1", manual, g.State, reveals)
+		}
+	}
+}
+
+func TestOnStateChangeSeesSettledRound(t *testing.T) {
+	// Player 10,9 against the dealer's 10,7.
+	g := stackedGame(DefaultRules(), Ten, Ten, Nine, Seven)
+	var result string
+	var net, bankroll, wins int
+	g.OnStateChange = func(_, new State) {
+		if new == RoundOver {
+			result, net, bankroll, wins = g.Result, g.Outcome.Net, g.Bankroll, g.stats.Wins
+		}
+	}
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerStand()
+	if result == "" || net != 10 || bankroll != DefaultBankroll+10 || wins != 1 {
+		t.Errorf("at RoundOver: result %q, net %d, bankroll %d, wins %d; want the settled round", result, net, bankroll, wins)
+	}
+}
+
+func TestTryDrawEmptyDeck(t *testing.T) {
+	d := NewStackedDeck(spades(Ten))
+	if c, ok := d.TryDraw(); !ok || c.Rank != Ten {
+		t.Fatalf("TryDraw = %v, %v, want the 10", c, ok)
+	}
+	if _, ok := d.TryDraw(); ok {
+		t.Error("TryDraw on an empty deck reported a card")
+	}
+}
+
+func TestStrictDeckExhaustedMidRound(t *testing.T) {
+	// The last four cards of the shoe deal the player 10,9 against the
+	// dealer's 10,2, who then needs a card the shoe doesn't have.
+	rules := DefaultRules()
+	rules.Penetration = 0
+	g := NewGameWithRules(rules, 1)
+	g.Deck.StrictDeck = true
+	g.Deck.cards = spades(Two, Nine, Ten, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	if len(g.PlayerHands[0].Cards) != 2 || g.Deck.Remaining() != 0 {
+		t.Fatalf("strict deck reshuffled for a round it could deal: %d cards left", g.Deck.Remaining())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("drawing past the end of a strict deck didn't panic")
+		}
+	}()
+	g.PlayerStand()
+}
+
+func TestStrictDeckReshufflesBetweenRounds(t *testing.T) {
+	rules := DefaultRules()
+	rules.Penetration = 0
+	g := NewGameWithRules(rules, 1)
+	g.Deck.StrictDeck = true
+	g.Deck.cards = spades(Nine, Ten, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	if want := g.Deck.Size() - rules.BurnCards - 4; g.Deck.Remaining() != want {
+		t.Errorf("3-card strict shoe dealt on: %d cards left, want a fresh shoe's %d", g.Deck.Remaining(), want)
+	}
+}
+
+func TestNoHoleCardDealsDealerOneCard(t *testing.T) {
+	rules := DefaultRules()
+	rules.NoHoleCard = true
+	g := stackedGame(rules, Ten, Six, Nine, Ten, Nine)
+	g.PlaceBet(10)
+	g.Deal()
+	if len(g.Dealer.Cards) != 1 || g.HoleCardHidden() {
+		t.Fatalf("dealer after the deal: %v, hole hidden %v", g.Dealer, g.HoleCardHidden())
+	}
+	g.PlayerStand()
+	if len(g.Dealer.Cards) != 3 || g.Bankroll != DefaultBankroll+10 {
+		t.Errorf("dealer drew to %v; bankroll %d", g.Dealer, g.Bankroll)
+	}
+}
+
+func TestNoHoleCardDoubleAgainstDealerBlackjack(t *testing.T) {
+	tests := []struct {
+		name  string
+		ranks []Rank
+	}{
+		// 6,5 doubles to 20 against an Ace that draws a King.
+		{"double to 20 vs A", []Rank{Six, Ace, Five, Nine, King}},
+		// 6,5 doubles to 21 against a 10 that draws an Ace.
+		{"double to 21 vs 10", []Rank{Six, Ten, Five, Ten, Ace}},
+	}
+	for _, tt := range tests {
+		rules := DefaultRules()
+		rules.NoHoleCard = true
+		g := stackedGame(rules, tt.ranks...)
+		g.PlaceBet(10)
+		g.Deal()
+		g.DeclineInsurance()
+		g.PlayerDoubleDown()
+		if g.State != RoundOver || !g.Dealer.IsBlackjack() {
+			t.Fatalf("%s: state %v, dealer %v", tt.name, g.State, g.Dealer)
+		}
+		if g.Bankroll != DefaultBankroll-20 || g.Outcome.Net != -20 {
+			t.Errorf("%s: bankroll %d, net %d, want the doubled 20 lost", tt.name, g.Bankroll, g.Outcome.Net)
+		}
+	}
+
+	// With a hole card and a peek the same natural ends the round before the
+	// double, costing only the original bet.
+	g := stackedGame(DefaultRules(), Six, Ten, Five, Ace, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerDoubleDown()
+	if g.Bankroll != DefaultBankroll-10 {
+		t.Errorf("peeked dealer natural: bankroll %d, want %d", g.Bankroll, DefaultBankroll-10)
+	}
+}
+
+func TestNoHoleCardNaturalPushesDealerNatural(t *testing.T) {
+	rules := DefaultRules()
+	rules.NoHoleCard = true
+	g := stackedGame(rules, Ace, Ten, King, Ace)
+	g.PlaceBet(10)
+	g.Deal()
+	if g.State != RoundOver || !g.Dealer.IsBlackjack() || g.Bankroll != DefaultBankroll {
+		t.Errorf("natural vs a 10 that draws an Ace: state %v, dealer %v, bankroll %d", g.State, g.Dealer, g.Bankroll)
+	}
+}
+
+func TestHandValueString(t *testing.T) {
+	tests := []struct {
+		ranks []Rank
+		want  string
+	}{
+		{[]Rank{Ace, King}, "BJ"},
+		{[]Rank{Ace, Six}, "soft 17"},
+		{[]Rank{King, Queen, Five}, "Bust"},
+		{[]Rank{Ten, Seven}, "17"},
+		{[]Rank{Seven, Four, King}, "21"},
+	}
+	for _, tt := range tests {
+		hand := Hand{Cards: spades(tt.ranks...)}
+		if got := hand.ValueString(); got != tt.want {
+			t.Errorf("%v ValueString() = %q, want %q", hand, got, tt.want)
+		}
+	}
+	split := Hand{Cards: spades(Ace, King), FromSplit: true}
+	if got := split.ValueString(); got != "soft 21" {
+		t.Errorf("split A,K ValueString() = %q, want %q", got, "soft 21")
+	}
+}
+
+func TestDealerWinsTies(t *testing.T) {
+	for _, ties := range []bool{false, true} {
+		// Player 10,8 stands against the dealer's 10,8.
+		rules := DefaultRules()
+		rules.DealerWinsTies = ties
+		g := stackedGame(rules, Ten, Ten, Eight, Eight)
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayerStand()
+		want := DefaultBankroll
+		if ties {
+			want -= 10
+		}
+		if g.Bankroll != want {
+			t.Errorf("DealerWinsTies %v: 18 vs 18 left bankroll %d, want %d (%s)", ties, g.Bankroll, want, g.Result)
+		}
+	}
+}
+
+func TestUpcomingOrderReplays(t *testing.T) {
+	d := NewDeckWithSeed(2, 7)
+	for range 30 {
+		d.Draw()
+	}
+	order := d.UpcomingOrder()
+	if len(order) != d.Remaining() {
+		t.Fatalf("UpcomingOrder has %d cards, want %d", len(order), d.Remaining())
+	}
+	top, _ := d.Peek()
+	if order[0] != top {
+		t.Errorf("UpcomingOrder()[0] = %v, want the top card %v", order[0], top)
+	}
+	order[0] = Card{Suit: Hearts, Rank: Ace}
+	order[1] = Card{Suit: Hearts, Rank: Ace}
+	if next, _ := d.Peek(); next != top {
+		t.Errorf("changing UpcomingOrder's result changed the top card to %v", next)
+	}
+
+	order = d.UpcomingOrder()
+	rebuilt := NewDeckFromOrder(order)
+	if rebuilt.RunningCount() != d.RunningCount() {
+		t.Errorf("rebuilt running count %d, want %d", rebuilt.RunningCount(), d.RunningCount())
+	}
+	for i := range order {
+		if got, want := rebuilt.Draw(), d.Draw(); got != want {
+			t.Fatalf("draw %d: rebuilt deck dealt %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestNaturalsResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranks  []Rank
+		result string
+		net    int
+	}{
+		{"both naturals", []Rank{Ace, Ten, King, Ace}, "Push — both blackjack.", 0},
+		{"player natural", []Rank{Ace, Nine, King, Nine}, "Player blackjack!", 15},
+		{"dealer natural", []Rank{Ten, Ten, Nine, Ace}, "Dealer blackjack.", -10},
+	}
+	for _, tt := range tests {
+		g := stackedGame(DefaultRules(), tt.ranks...)
+		g.PlaceBet(10)
+		g.Deal()
+		if g.State != RoundOver {
+			t.Fatalf("%s: round not settled on the deal: %v", tt.name, g.State)
+		}
+		if g.Result != tt.result || g.Bankroll != DefaultBankroll+tt.net {
+			t.Errorf("%s: result %q, bankroll %d; want %q, %d", tt.name, g.Result, g.Bankroll, tt.result, DefaultBankroll+tt.net)
+		}
+	}
+}
+
+func TestDeckDiscardedPlusRemaining(t *testing.T) {
+	rules := DefaultRules()
+	rules.Decks = 1
+	rules.Penetration = 0.75
+	rules.BurnCards = 1
+	g := NewGameWithRules(rules, 5)
+	g.Bankroll = 1 << 20
+	shuffled, last := false, g.Deck.Discarded()
+	for round := 0; round < 40; round++ {
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayPlayerWith(BasicStrategyPlayer{})
+		// The tray only empties when the shoe is reshuffled.
+		if g.Deck.Discarded() < last {
+			shuffled = true
+		}
+		last = g.Deck.Discarded()
+		if got := g.Deck.Discarded() + g.Deck.Remaining(); got != g.Deck.Size() {
+			t.Fatalf("round %d: %d discarded + %d remaining = %d, want %d", round, g.Deck.Discarded(), g.Deck.Remaining(), got, g.Deck.Size())
+		}
+	}
+	if !shuffled {
+		t.Error("40 single-deck rounds never reshuffled")
+	}
+}
+
+func TestCardBlackjackValue(t *testing.T) {
+	want := map[Rank]int{
+		Ace: 11, Two: 2, Three: 3, Four: 4, Five: 5, Six: 6, Seven: 7,
+		Eight: 8, Nine: 9, Ten: 10, Jack: 10, Queen: 10, King: 10,
+	}
+	for r := Ace; r <= King; r++ {
+		c := Card{Suit: Clubs, Rank: r}
+		if got := c.BlackjackValue(); got != want[r] {
+			t.Errorf("%v BlackjackValue() = %d, want %d", c, got, want[r])
+		}
+	}
+}
+
+func TestDeckShuffleFunc(t *testing.T) {
+	d := NewDeckWithSeed(1, 1)
+	calls := 0
+	// Leaving the cards in the order they were gathered deals ranks in turn.
+	d.ShuffleFunc = func(cards []Card, _ *rand.Rand) {
+		calls++
+		if len(cards) != 52 {
+			t.Errorf("ShuffleFunc got %d cards, want 52", len(cards))
+		}
+	}
+	for d.Remaining() > 0 {
+		d.Draw()
+	}
+	first := d.Draw()
+	if calls != 1 {
+		t.Fatalf("ShuffleFunc called %d times, want once", calls)
+	}
+	if next, _ := d.Peek(); first != (Card{Spades, King}) || next != (Card{Spades, Queen}) {
+		t.Errorf("unshuffled shoe dealt %v then %v, want K♠ then Q♠", first, next)
+	}
+}
+
+func TestDeckOnShuffleOncePerReset(t *testing.T) {
+	d := NewDeckWithSeed(1, 1)
+	shuffles := 0
+	d.OnShuffle = func() {
+		shuffles++
+		if d.Remaining() != 52 || d.RunningCount() != 0 {
+			t.Errorf("OnShuffle saw %d cards, count %d; want a fresh shoe", d.Remaining(), d.RunningCount())
+		}
+	}
+	for i := 0; i < 3*52; i++ {
+		d.Draw()
+	}
+	if shuffles != 2 {
+		t.Errorf("drawing three decks' worth fired OnShuffle %d times, want 2", shuffles)
+	}
+}
+
+func TestGameOnShuffleAtCutCard(t *testing.T) {
+	rules := DefaultRules()
+	rules.Decks = 1
+	rules.Penetration = 0.5
+	g := NewGameWithRules(rules, 1)
+	g.Bankroll = 1 << 20
+	shuffles := 0
+	g.Deck.OnShuffle = func() { shuffles++ }
+	for g.Deck.Remaining() > 26 {
+		g.PlaceBet(10)
+		g.Deal()
+		g.PlayPlayerWith(BasicStrategyPlayer{})
+	}
+	if shuffles != 0 {
+		t.Fatalf("shuffled %d times before the cut card", shuffles)
+	}
+	g.PlaceBet(10)
+	g.Deal()
+	if shuffles != 1 {
+		t.Errorf("dealing past the cut card fired OnShuffle %d times, want once", shuffles)
+	}
+}
+
+func TestAvailableActions(t *testing.T) {
+	tests := []struct {
+		name  string
+		ranks []Rank
+		hit   bool
+		want  []Action
+	}{
+		{"fresh 10,6", []Rank{Ten, Ten, Six, Seven}, false, []Action{Hit, Stand, Double, Surrender}},
+		{"fresh pair", []Rank{Eight, Ten, Eight, Seven}, false, []Action{Hit, Stand, Double, Split, Surrender}},
+		{"after a hit", []Rank{Ten, Ten, Two, Seven, Three}, true, []Action{Hit, Stand}},
+	}
+	for _, tt := range tests {
+		g := stackedGame(DefaultRules(), tt.ranks...)
+		g.PlaceBet(10)
+		g.Deal()
+		if tt.hit {
+			g.PlayerHit()
+		}
+		if got := g.AvailableActions(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: AvailableActions() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAvailableActionsAfterSplit(t *testing.T) {
+	// 8,8 splits into 8,3 and 8,8: no surrender on split hands, and the
+	// second pair may split again.
+	g := stackedGame(DefaultRules(), Eight, Ten, Eight, Seven, Three, Eight)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	if got, want := g.AvailableActions(), []Action{Hit, Stand, Double}; !slices.Equal(got, want) {
+		t.Errorf("first split hand: AvailableActions() = %v, want %v", got, want)
+	}
+	g.PlayerStand()
+	if got, want := g.AvailableActions(), []Action{Hit, Stand, Double, Split}; !slices.Equal(got, want) {
+		t.Errorf("second split hand: AvailableActions() = %v, want %v", got, want)
+	}
+	g.PlayerStand()
+	if got, want := g.AvailableActions(), []Action{DealNext}; !slices.Equal(got, want) {
+		t.Errorf("after the round: AvailableActions() = %v, want %v", got, want)
+	}
+}
+
+func TestAvailableActionsNaturalAgainstAce(t *testing.T) {
+	// Player A,K against the dealer's A,9: the natural waits on even money.
+	g := stackedGame(DefaultRules(), Ace, Ace, King, Nine)
+	g.PlaceBet(10)
+	g.Deal()
+	if !g.OfferEvenMoney() {
+		t.Fatalf("even money not offered: state %v", g.State)
+	}
+	if got, want := g.AvailableActions(), []Action{Stand}; !slices.Equal(got, want) {
+		t.Errorf("AvailableActions() = %v, want %v", got, want)
+	}
+	if g.CanDoubleDown() || g.CanSurrender() {
+		t.Errorf("CanDoubleDown %v, CanSurrender %v on a natural", g.CanDoubleDown(), g.CanSurrender())
+	}
+}
+
+func TestDealtNaturalEndsTheRound(t *testing.T) {
+	g := stackedGame(DefaultRules(), Ace, Nine, King, Seven)
+	g.PlaceBet(10)
+	g.Deal()
+	if g.State != RoundOver || g.Result == "" || g.Outcome.Net != 15 {
+		t.Fatalf("after a dealt natural: state %v, result %q, net %d; want the round settled", g.State, g.Result, g.Outcome.Net)
+	}
+	if got, want := g.AvailableActions(), []Action{DealNext}; !slices.Equal(got, want) {
+		t.Errorf("AvailableActions() = %v, want %v", got, want)
+	}
+	g.PlayerHit()
+	g.PlayerStand()
+	if len(g.PlayerHands[0].Cards) != 2 || g.Bankroll != 1015 {
+		t.Errorf("plays after the natural changed the round: %v, bankroll %d", g.PlayerHands[0], g.Bankroll)
+	}
+}
+
+func TestDealtNaturalAgainstAceEndsOnTheOffer(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  func(*Game)
+		wantNet int
+	}{
+		{"stand", (*Game).PlayerStand, 15},
+		{"decline", (*Game).DeclineInsurance, 15},
+		{"even money", (*Game).TakeEvenMoney, 10},
+	}
+	for _, tt := range tests {
+		// Player A,K against the dealer's A,9: the round waits on even money.
+		g := stackedGame(DefaultRules(), Ace, Ace, King, Nine)
+		g.PlaceBet(10)
+		g.Deal()
+		if g.State != PlayerTurn || g.Result != "" {
+			t.Fatalf("%s: before the offer is answered: state %v, result %q", tt.name, g.State, g.Result)
+		}
+		tt.answer(g)
+		if g.State != RoundOver || g.Result == "" || g.Outcome.Net != tt.wantNet {
+			t.Errorf("%s: state %v, result %q, net %d; want the round settled for %d", tt.name, g.State, g.Result, g.Outcome.Net, tt.wantNet)
+		}
+		if got, want := g.AvailableActions(), []Action{DealNext}; !slices.Equal(got, want) {
+			t.Errorf("%s: AvailableActions() = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestDealerValueString(t *testing.T) {
+	// The dealer's 6 up over an Ace in the hole is a soft 17 once shown.
+	g := stackedGame(DefaultRules(), Ten, Six, Nine, Ace)
+	if got := g.DealerValueString(); got != "" {
+		t.Errorf("before the deal: DealerValueString() = %q, want empty", got)
+	}
+	g.PlaceBet(10)
+	g.Deal()
+	if got := g.DealerValueString(); got != "6" {
+		t.Errorf("during the player's turn: DealerValueString() = %q, want the upcard's %q", got, "6")
+	}
+	g.ManualDealer = true
+	g.PlayerStand()
+	g.RevealHoleCard()
+	if got := g.DealerValueString(); got != "soft 17" {
+		t.Errorf("hole card shown: DealerValueString() = %q, want %q", got, "soft 17")
+	}
+}
+
+func TestRebet(t *testing.T) {
+	// 10,9 beats the dealer's 10,8, then the rebet deals 10,7 against 10,6,
+	// who draws a 10.
+	g := stackedGame(DefaultRules(), Ten, Ten, Nine, Eight, Ten, Ten, Seven, Six, Ten)
+	if err := g.Rebet(); !errors.Is(err, ErrNoBet) {
+		t.Errorf("Rebet before any bet = %v, want %v", err, ErrNoBet)
+	}
+	g.PlaceBet(25)
+	g.Deal()
+	g.PlayerStand()
+	if g.Bankroll != DefaultBankroll+25 {
+		t.Fatalf("after the win: bankroll %d", g.Bankroll)
+	}
+	if err := g.Rebet(); err != nil {
+		t.Fatalf("Rebet: %v", err)
+	}
+	if g.State != PlayerTurn || g.Bankroll != DefaultBankroll || g.PlayerHands[0].Bet != 25 {
+		t.Errorf("after Rebet: state %v, bankroll %d, bet %d", g.State, g.Bankroll, g.PlayerHands[0].Bet)
+	}
+	if hand := g.PlayerHands[0]; len(hand.Cards) != 2 || hand.Cards[1].Rank != Seven {
+		t.Errorf("Rebet dealt %v, want a fresh 10,7", hand)
+	}
+
+	g.PlayerStand()
+	g.Bankroll = 10
+	if err := g.Rebet(); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Rebet of 25 with 10 left = %v, want %v", err, ErrInsufficientFunds)
+	}
+}
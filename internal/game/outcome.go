@@ -0,0 +1,100 @@
+package game
+
+import "strings"
+
+// RoundOutcome is the settlement of a finished round.
+type RoundOutcome struct {
+	Hands    []HandOutcome
+	SideBets []SideBetOutcome
+	// Insured and InsuranceWon report the insurance bet, if one was taken.
+	Insured      bool
+	InsuranceWon bool
+	// Net is the round's change to the bankroll, insurance included, and
+	// Units is Net in units of the first seat's bet.
+	Net   int
+	Units float64
+}
+
+// HandOutcome is the settlement of one player hand.
+type HandOutcome struct {
+	// Label names the hand when the round had more than one, e.g. "Hand 2".
+	Label  string
+	Result string
+	// Key and Args are the message Result was written from, for a Localizer
+	// to write again.
+	Key  MessageKey
+	Args []int
+	// Outcome is +1 for a win, -1 for a loss and 0 for a push.
+	Outcome     int
+	Surrendered bool
+	// Net is the hand's change to the bankroll: its winnings, or minus the
+	// stake lost.
+	Net int
+}
+
+// String describes the round in English. A single hand gets its full
+// result; several hands are summarized with the net, e.g. "Hand 1 win, Hand
+// 2 push; net +1.0". Side bets follow either way.
+func (o RoundOutcome) String() string {
+	return o.Localize(English)
+}
+
+// Localize is String in the language of l. Hand labels stay as the game
+// wrote them.
+func (o RoundOutcome) Localize(l Localizer) string {
+	s := o.hands(l)
+	for _, side := range o.SideBets {
+		if side.Multiplier > 0 {
+			s += " " + l.Message(MsgSideBetWon, side.Name, side.Category, side.Multiplier)
+		} else {
+			s += " " + l.Message(MsgSideBetLost, side.Name)
+		}
+	}
+	return s
+}
+
+func (o RoundOutcome) hands(l Localizer) string {
+	if len(o.Hands) == 1 {
+		s := o.Hands[0].result(l)
+		switch {
+			case o.InsuranceWon:
+				s += " " + l.Message(MsgInsuranceWon)
+			case o.Insured:
+				s += " " + l.Message(MsgInsuranceLost)
+		}
+		return s
+	}
+	parts := make([]string, 0, len(o.Hands)+1)
+	for _, h := range o.Hands {
+		parts = append(parts, l.Message(h.summaryKey(), h.Label))
+	}
+	switch {
+		case o.InsuranceWon:
+			parts = append(parts, l.Message(MsgSummaryInsuranceWon))
+		case o.Insured:
+			parts = append(parts, l.Message(MsgSummaryInsuranceLost))
+	}
+	return l.Message(MsgSummaryNet, strings.Join(parts, ", "), o.Units)
+}
+
+// result is the hand's Result written by l, or Result as saved if it has no
+// key, as in outcomes saved before keys were kept.
+func (h HandOutcome) result(l Localizer) string {
+	if h.Key == "" {
+		return h.Result
+	}
+	return message(l, h.Key, h.Args)
+}
+
+func (h HandOutcome) summaryKey() MessageKey {
+	switch {
+		case h.Surrendered:
+			return MsgSummarySurrender
+		case h.Outcome > 0:
+			return MsgSummaryWin
+		case h.Outcome < 0:
+			return MsgSummaryLoss
+		default:
+			return MsgSummaryPush
+	}
+}
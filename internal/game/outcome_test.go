@@ -0,0 +1,27 @@
+package game
+
+import "testing"
+
+func TestSplitRoundOutcome(t *testing.T) {
+	// 8,8 against the dealer's 10,8: one hand draws to 19 and wins, the
+	// other to 17 and loses.
+	g := stackedGame(DefaultRules(), Eight, Ten, Eight, Eight, Ace, Nine)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayerSplit()
+	g.PlayerStand()
+	g.PlayerStand()
+	if g.State != RoundOver {
+		t.Fatalf("round not over: %v", g.State)
+	}
+	hands := g.Outcome.Hands
+	if len(hands) != 2 || hands[0].Net != 10 || hands[1].Net != -10 {
+		t.Fatalf("hand outcomes = %+v, want a win then a loss", hands)
+	}
+	if g.Outcome.Net != 0 || g.Bankroll != DefaultBankroll {
+		t.Errorf("net %d, bankroll %d, want even", g.Outcome.Net, g.Bankroll)
+	}
+	if want := "Hand 1 win, Hand 2 loss; net +0.0"; g.Result != want {
+		t.Errorf("Result = %q, want %q", g.Result, want)
+	}
+}
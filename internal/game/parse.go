@@ -0,0 +1,85 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+var rankCodes = map[Rank]string{
+	Ace:   "A",
+	Two:   "2",
+	Three: "3",
+	Four:  "4",
+	Five:  "5",
+	Six:   "6",
+	Seven: "7",
+	Eight: "8",
+	Nine:  "9",
+	Ten:   "T",
+	Jack:  "J",
+	Queen: "Q",
+	King:  "K",
+}
+
+var suitCodes = map[Suit]string{
+	Clubs:    "C",
+	Diamonds: "D",
+	Hearts:   "H",
+	Spades:   "S",
+}
+
+// Code returns the card in compact ASCII notation, rank then suit letter,
+// e.g. "AS", "TH" or "5D". ParseCard reads it back.
+func (c Card) Code() string {
+	return rankCodes[c.Rank] + suitCodes[c.Suit]
+}
+
+// ParseCard reads a card in the notation produced by Code. "10" is accepted
+// as well as "T" for tens, and letters may be lower case.
+func ParseCard(s string) (Card, error) {
+	code := strings.ToUpper(s)
+	if len(code) < 2 {
+		return Card{}, fmt.Errorf("unknown card %q", s)
+	}
+	rankCode, suitCode := code[:len(code)-1], code[len(code)-1:]
+	if rankCode == "10" {
+		rankCode = "T"
+	}
+
+	var card Card
+	found := false
+	for r, rc := range rankCodes {
+		if rc == rankCode {
+			card.Rank = r
+			found = true
+		}
+	}
+	if !found {
+		return Card{}, fmt.Errorf("unknown card %q", s)
+	}
+	found = false
+	for su, sc := range suitCodes {
+		if sc == suitCode {
+			card.Suit = su
+			found = true
+		}
+	}
+	if !found {
+		return Card{}, fmt.Errorf("unknown card %q", s)
+	}
+	return card, nil
+}
+
+// ParseHand reads a whitespace-separated list of card codes such as
+// "AS KH 5D" into a Hand.
+func ParseHand(s string) (Hand, error) {
+	var h Hand
+	for _, tok := range strings.Fields(s) {
+		card, err := ParseCard(tok)
+		if err != nil {
+			return Hand{}, err
+		}
+		h.Add(card)
+	}
+	return h, nil
+}
@@ -0,0 +1,98 @@
+package game
+
+// DealerBustProbability returns the chance that a dealer showing upcard
+// finishes over 21, drawing the hole card and any hits from the cards left in
+// deck. Every possible draw is enumerated, weighted by how many of each card
+// remain, so the odds follow the actual shoe composition. The dealer is not
+// assumed to have peeked, so a natural counts as a hand that didn't bust.
+func DealerBustProbability(upcard Card, deck *Deck, hitsSoft17 bool) float64 {
+	counts, left := deck.valueCounts()
+	value := cardValue(upcard)
+	return dealerBust(&counts, left, value, value == 1, hitsSoft17)
+}
+
+// DealerBlackjackProbability returns the chance that the dealer's hole card
+// makes a natural with upcard, from the cards left in deck: a ten-value card
+// under an Ace, an Ace under a ten. Insurance pays off when this exceeds 1/3.
+func DealerBlackjackProbability(upcard Card, deck *Deck) float64 {
+	counts, left := deck.valueCounts()
+	if left == 0 {
+		return 0
+	}
+	switch cardValue(upcard) {
+		case 1:
+			return float64(counts[10]) / float64(left)
+		case 10:
+			return float64(counts[1]) / float64(left)
+		default:
+			return 0
+	}
+}
+
+// valueCounts returns the number of cards of each blackjack value left in the
+// deck, indexed 1 (aces) to 10, and their total.
+func (d *Deck) valueCounts() (counts [11]int, total int) {
+	for r, n := range d.RankCounts() {
+		counts[min(r, 10)] += n
+	}
+	return counts, len(d.cards)
+}
+
+// cardValue is the card's hard value: BlackjackValue with aces 1.
+func cardValue(c Card) int {
+	if c.Rank == Ace {
+		return 1
+	}
+	return c.BlackjackValue()
+}
+
+// dealerBust returns the chance the dealer busts from a hard total, with ace
+// set if the hand holds an ace, drawing from counts. Each draw raises the
+// total, so the recursion is at most 17 cards deep. If the shoe runs out the
+// dealer is taken to stand.
+func dealerBust(counts *[11]int, left, hard int, ace, hitsSoft17 bool) float64 {
+	total, soft := hard, false
+	if ace && hard+10 <= 21 {
+		total, soft = hard+10, true
+	}
+	switch {
+		case total > 21:
+			return 1
+		case total > 17, total == 17 && !(soft && hitsSoft17):
+			return 0
+		case left == 0:
+			return 0
+	}
+	p := 0.0
+	for v := 1; v <= 10; v++ {
+		n := counts[v]
+		if n == 0 {
+			continue
+		}
+		counts[v]--
+		p += float64(n) / float64(left) * dealerBust(counts, left-1, hard+v, ace || v == 1, hitsSoft17)
+		counts[v]++
+	}
+	return p
+}
+
+// BustProbability returns the chance that hitting busts the hand, weighted by
+// the cards left in deck. It is 1 for a hand that is already bust and 0 when
+// the deck is empty.
+func (h Hand) BustProbability(deck *Deck) float64 {
+	hard, _, _ := h.Totals()
+	if hard > 21 {
+		return 1
+	}
+	counts, left := deck.valueCounts()
+	if left == 0 {
+		return 0
+	}
+	busts := 0
+	for v := 1; v <= 10; v++ {
+		if hard+v > 21 {
+			busts += counts[v]
+		}
+	}
+	return float64(busts) / float64(left)
+}
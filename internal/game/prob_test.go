@@ -0,0 +1,58 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDealerBustProbabilitySix(t *testing.T) {
+	deck := NewDeckWithSeed(6, 1)
+	six := Card{Suit: Spades, Rank: Six}
+	// An infinite deck busts a 6 42.3% of the time standing on soft 17 and
+	// 43.9% hitting it; a six-deck shoe is within a fraction of that.
+	for _, tt := range []struct {
+		hitsSoft17 bool
+		want       float64
+	}{{false, 0.423}, {true, 0.439}} {
+		if got := DealerBustProbability(six, deck, tt.hitsSoft17); math.Abs(got-tt.want) > 0.005 {
+			t.Errorf("hitsSoft17 %v: bust with a 6 up = %.4f, want about %.3f", tt.hitsSoft17, got, tt.want)
+		}
+	}
+}
+
+func TestHandBustProbability(t *testing.T) {
+	twelve := Hand{Cards: spades(Ten, Two)}
+	// Any ten-value card busts a hard 12: 16 cards in 52.
+	if got := twelve.BustProbability(NewDeckWithSeed(6, 1)); math.Abs(got-16.0/52) > 1e-9 {
+		t.Errorf("hard 12 on a full shoe busts %.4f, want %.4f", got, 16.0/52)
+	}
+	fifteen := Hand{Cards: spades(Ten, Five)}
+	deck := NewStackedDeck(spades(Ten, Two, Seven, Six))
+	if got := fifteen.BustProbability(deck); got != 0.5 {
+		t.Errorf("15 on 10,2,7,6 busts %v, want 0.5", got)
+	}
+	if got := (Hand{Cards: spades(Ace, Five)}).BustProbability(deck); got != 0 {
+		t.Errorf("soft 16 busts %v, want 0", got)
+	}
+}
+
+func TestDealerBlackjackProbability(t *testing.T) {
+	ace := Card{Suit: Spades, Rank: Ace}
+	deck := NewDeckWithSeed(1, 1)
+	if got := DealerBlackjackProbability(ace, deck); math.Abs(got-16.0/52) > 1e-9 {
+		t.Errorf("full deck: %.4f, want %.4f", got, 16.0/52)
+	}
+	for s := Clubs; s <= Spades; s++ {
+		for r := Ten; r <= King; r++ {
+			if s != Spades || r != King {
+				deck.Remove(Card{Suit: s, Rank: r})
+			}
+		}
+	}
+	if got := DealerBlackjackProbability(ace, deck); math.Abs(got-1.0/37) > 1e-9 {
+		t.Errorf("one ten left in 37 cards: %.4f, want %.4f", got, 1.0/37)
+	}
+	if got := DealerBlackjackProbability(Card{Rank: Nine}, deck); got != 0 {
+		t.Errorf("a 9 upcard can't make a natural, got %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import "slices"
+
+// ReplayBet is the bet Replay places on every round.
+const ReplayBet = 10
+
+// RecordedRound is one round of a recorded session: the bets placed for the
+// deal, one per seat, and the player actions that stood, in order.
+type RecordedRound struct {
+	Bets    []int
+	Actions []Action
+}
+
+// Recorder captures a session for replay: the seed its game was dealt from
+// and, round by round, the bets and every player action that stood. Undone
+// actions are left out.
+type Recorder struct {
+	Seed   int64
+	game   *Game
+	rounds []RecordedRound
+}
+
+// NewRecordedGame returns a game under DefaultRules dealt from seed, and the
+// Recorder logging it. ReplayRounds reproduces the session as long as
+// insurance and even money are declined and the table rules are left alone.
+func NewRecordedGame(seed int64) (*Game, *Recorder) {
+	g := NewGameWithSeed(DefaultRules().Decks, seed)
+	r := &Recorder{Seed: seed, game: g}
+	g.recorder = r
+	return g, r
+}
+
+// Rounds returns the rounds recorded so far, including the one in progress.
+func (r *Recorder) Rounds() []RecordedRound {
+	g := r.game
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	rounds := slices.Clone(r.rounds)
+	if g.State == PlayerTurn || g.State == DealerTurn {
+		rounds = append(rounds, RecordedRound{Bets: slices.Clone(g.Bets), Actions: slices.Clone(g.actions)})
+	}
+	return rounds
+}
+
+// Actions returns the actions recorded so far, including those of the round
+// in progress, for Replay.
+func (r *Recorder) Actions() []Action {
+	var actions []Action
+	for _, round := range r.Rounds() {
+		actions = append(actions, round.Actions...)
+	}
+	return actions
+}
+
+// record adds the round just finished.
+func (r *Recorder) record(g *Game) {
+	r.rounds = append(r.rounds, RecordedRound{Bets: slices.Clone(g.Bets), Actions: slices.Clone(g.actions)})
+}
+
+// ReplayRounds plays rounds on a new game dealt from seed and returns it in
+// its final state. Each round is dealt with its bets and insurance declined,
+// then its actions are played, so a Recorder's seed and rounds rebuild the
+// recorded game. It stops early at a round whose bets the game refuses.
+func ReplayRounds(seed int64, rounds []RecordedRound) *Game {
+	g := NewGameWithSeed(DefaultRules().Decks, seed)
+	for _, round := range rounds {
+		if g.PlaceBets(round.Bets...) != nil {
+			return g
+		}
+		g.Deal()
+		g.DeclineInsurance()
+		for _, action := range round.Actions {
+			g.playAction(action)
+		}
+	}
+	return g
+}
+
+// Replay plays actions on a new game dealt from seed and returns it in its
+// final state. A round of ReplayBet on one seat is dealt, and insurance
+// declined, whenever an action comes between rounds, including rounds that
+// end on the deal. It only rebuilds sessions played at that stake, and not
+// a last round dealt but not yet acted on; ReplayRounds has neither limit.
+func Replay(seed int64, actions []Action) *Game {
+	g := NewGameWithSeed(DefaultRules().Decks, seed)
+	for _, action := range actions {
+		for g.State != PlayerTurn {
+			if g.PlaceBet(ReplayBet) != nil {
+				return g
+			}
+			g.Deal()
+			g.DeclineInsurance()
+		}
+		g.playAction(action)
+	}
+	return g
+}
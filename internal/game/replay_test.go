@@ -0,0 +1,54 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+// playSession plays rounds on g at a range of stakes, undoing a hit now and
+// then, and leaves the last round in progress.
+func playSession(g *Game, rounds int, bet func(round int) int) {
+	for i := 0; i < rounds; i++ {
+		g.PlaceBet(bet(i))
+		g.Deal()
+		if i%3 == 0 {
+			g.DeclineInsurance()
+			g.PlayerHit()
+			g.Undo()
+		}
+		if i < rounds-1 {
+			g.PlayPlayerWith(BasicStrategyPlayer{})
+		}
+	}
+}
+
+func sameTable(t *testing.T, got, want *Game) {
+	t.Helper()
+	if got.State != want.State || got.Bankroll != want.Bankroll || got.Result != want.Result {
+		t.Errorf("replayed %v, bankroll %d, %q; recorded %v, bankroll %d, %q",
+			got.State, got.Bankroll, got.Result, want.State, want.Bankroll, want.Result)
+	}
+	hands := func(g *Game) []string {
+		var s []string
+		for _, hand := range append(slices.Clone(g.PlayerHands), g.Dealer) {
+			s = append(s, hand.String())
+		}
+		return s
+	}
+	if !slices.Equal(hands(got), hands(want)) {
+		t.Errorf("replayed hands %v, recorded %v", hands(got), hands(want))
+	}
+}
+
+func TestReplayRounds(t *testing.T) {
+	g, r := NewRecordedGame(11)
+	playSession(g, 60, func(round int) int { return 5 + round%7*25 })
+	sameTable(t, ReplayRounds(r.Seed, r.Rounds()), g)
+}
+
+func TestReplay(t *testing.T) {
+	g, r := NewRecordedGame(12)
+	playSession(g, 60, func(int) int { return ReplayBet })
+	g.PlayPlayerWith(BasicStrategyPlayer{})
+	sameTable(t, Replay(r.Seed, r.Actions()), g)
+}
@@ -0,0 +1,77 @@
+package game
+
+// Rules bundles the table configuration: the shoe, payouts, how the dealer
+// plays and which player options are offered. Start from DefaultRules and
+// change what differs.
+type Rules struct {
+	Decks int
+	// BlackjackPayout is the multiple of the bet won on a natural, e.g. 1.5
+	// for 3:2 or 1.2 for 6:5. It must be positive. Winnings are paid in
+	// whole chips, rounded down, so a 5 bet at 3:2 wins 7.
+	BlackjackPayout float64
+	// DealerHitsSoft17 makes the dealer draw on a soft 17; otherwise the
+	// dealer stands on all 17s.
+	DealerHitsSoft17 bool
+	// DealerPeek makes the dealer check for blackjack under an Ace or
+	// ten-value upcard before the player acts, ending the round at once on a
+	// natural. Without it a dealer natural is only revealed on the dealer's
+	// turn.
+	DealerPeek bool
+	// NoHoleCard deals the dealer a single card, European style, and the
+	// second only once the player is done, so there is nothing to peek at. A
+	// dealer natural then takes everything staked on doubles and splits.
+	NoHoleCard bool
+	// FiveCardCharlie awards an automatic win to a hand that reaches five
+	// cards without busting.
+	FiveCardCharlie bool
+	// SplitAcesOneCard deals split aces one card each and ends both hands
+	// without further action.
+	SplitAcesOneCard bool
+	// MaxSplits caps the splits a seat may make in a round, e.g. 3 for up to
+	// four hands. Zero disallows splitting.
+	MaxSplits int
+	// ResplitAces lets a split ace that draws another ace split again,
+	// within MaxSplits. It only matters without SplitAcesOneCard, which ends
+	// split aces at once.
+	ResplitAces bool
+	// DoubleAfterSplit allows doubling down on a hand made by a split.
+	DoubleAfterSplit bool
+	// Surrender allows late surrender of the opening two cards.
+	Surrender bool
+	// AutoStandOn21 ends a hand as soon as a hit brings it to 21.
+	AutoStandOn21 bool
+	// DealerWinsTies settles every tie, two naturals included, as a loss
+	// instead of a push.
+	DealerWinsTies bool
+
+	// Penetration, ContinuousShuffle and BurnCards configure the shoe; see
+	// the Deck fields of the same names.
+	Penetration       float64
+	ContinuousShuffle bool
+	BurnCards         int
+
+	// MinBet and MaxBet are the table limits on each bet PlaceBets takes.
+	// Zero leaves that end unlimited.
+	MinBet int
+	MaxBet int
+}
+
+// DefaultRules returns the rules a NewGame plays under: a six-deck shoe dealt
+// to the end, 3:2 naturals, dealer hits soft 17 and peeks, split aces get one
+// card, up to three splits with doubling after them, late surrender, and
+// hands stand by themselves on 21, with bets from 5 to 500.
+func DefaultRules() Rules {
+	return Rules{
+		Decks:            6,
+		BlackjackPayout:  DefaultBlackjackPayout,
+		DealerHitsSoft17: true,
+		DealerPeek:       true,
+		SplitAcesOneCard: true,
+		MaxSplits:        3,
+		DoubleAfterSplit: true,
+		Surrender:        true,
+		AutoStandOn21:    true,
+		MinBet:           5,
+		MaxBet:           500,
+	}
+}
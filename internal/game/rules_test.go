@@ -0,0 +1,25 @@
+package game
+
+import "testing"
+
+func TestDefaultRules(t *testing.T) {
+	want := Rules{
+		Decks:            6,
+		BlackjackPayout:  1.5,
+		DealerHitsSoft17: true,
+		DealerPeek:       true,
+		SplitAcesOneCard: true,
+		MaxSplits:        3,
+		DoubleAfterSplit: true,
+		Surrender:        true,
+		AutoStandOn21:    true,
+		MinBet:           5,
+		MaxBet:           500,
+	}
+	if got := DefaultRules(); got != want {
+		t.Errorf("DefaultRules() = %+v, want %+v", got, want)
+	}
+	if g := NewGame(6); g.Rules != want {
+		t.Errorf("NewGame(6).Rules = %+v, want the defaults", g.Rules)
+	}
+}
@@ -0,0 +1,42 @@
+package game
+
+import "slices"
+
+// SetupScenario is for tests and tools: it puts player and dealer on the
+// table as they are and moves the game to state, bypassing betting and the
+// deal entirely. No stake is taken from the bankroll, but the player hand's
+// Bet is settled as usual. The deck is left untouched, so any further cards
+// come from it.
+//
+// In PlayerTurn the player acts next. DealerTurn plays the dealer's turn at
+// once and RoundOver settles the round at once, so SetupScenario can drive
+// the result of a round directly.
+func (g *Game) SetupScenario(player, dealer Hand, state State) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	player.Cards = slices.Clone(player.Cards)
+	dealer.Cards = slices.Clone(dealer.Cards)
+	g.PlayerHands = []Hand{player}
+	g.Bets = []int{player.Bet}
+	g.Bet = player.Bet
+	g.Active = 0
+	g.Dealer = dealer
+	g.holeRevealed = false
+	g.Result = ""
+	g.Outcome = RoundOutcome{}
+	g.Insured = false
+	g.insuranceOpen = false
+	g.insuranceBet = 0
+	g.betPlaced = false
+	g.undo = g.undo[:0]
+	g.actions = nil
+	g.sideBets = nil
+	switch state {
+		case DealerTurn:
+			g.playDealer()
+		case RoundOver:
+			g.finishRound()
+		default:
+			g.setState(state)
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import "testing"
+
+func TestSetupScenarioResults(t *testing.T) {
+	tests := []struct {
+		player, dealer string
+		surrendered    bool
+		evenMoney      bool
+		charlie        bool
+		result         string
+		net            int
+	}{
+		{player: "AS KS", dealer: "AH KH", result: "Push — both blackjack.", net: 0},
+		{player: "TS 8S", dealer: "9H 9C", result: "Push. (18 vs 18)", net: 0},
+		{player: "AS KS", dealer: "9H 9S", result: "Player blackjack!", net: 15},
+		{player: "TS 9S", dealer: "9H 5S 8C", result: "Dealer busts (22). Player wins!", net: 10},
+		{player: "TS 9S", dealer: "9C 9D", result: "Player wins! (19 vs 18)", net: 10},
+		{player: "TS 9S 5H", dealer: "9H 9S", result: "Player busts (24). Dealer wins.", net: -10},
+		{player: "TH 9H", dealer: "AD KD", result: "Dealer blackjack.", net: -10},
+		{player: "TS 8S", dealer: "9H TS", result: "Dealer wins. (19 vs 18)", net: -10},
+		{player: "TS 6S", dealer: "9H TS", surrendered: true, result: "Player surrenders (loses half bet).", net: -5},
+		{player: "AS KS", dealer: "AH 9H", evenMoney: true, result: "Even money. Player wins 1:1.", net: 10},
+		{player: "2S 3S 2H 4H 5C", dealer: "TH 9H", charlie: true, result: "Five-card Charlie! Player wins.", net: 10},
+	}
+	for _, tt := range tests {
+		player, err := ParseHand(tt.player)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dealer, err := ParseHand(tt.dealer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		player.Bet = 10
+		player.Surrendered = tt.surrendered
+		player.EvenMoney = tt.evenMoney
+		g := NewGame(1)
+		g.Rules.FiveCardCharlie = tt.charlie
+		g.SetupScenario(player, dealer, RoundOver)
+		if g.Result != tt.result {
+			t.Errorf("%s v %s: Result = %q, want %q", tt.player, tt.dealer, g.Result, tt.result)
+		}
+		if g.Outcome.Net != tt.net || g.Bankroll != DefaultBankroll+10+tt.net {
+			t.Errorf("%s v %s: net %d, bankroll %d, want net %d", tt.player, tt.dealer, g.Outcome.Net, g.Bankroll, tt.net)
+		}
+	}
+}
+
+func TestSetupScenarioDealerTurn(t *testing.T) {
+	player, _ := ParseHand("TS 9S")
+	dealer, _ := ParseHand("TH 6H")
+	player.Bet = 10
+	g := stackedGame(DefaultRules(), Five)
+	g.SetupScenario(player, dealer, DealerTurn)
+	if g.State != RoundOver || g.Result != "Dealer wins. (21 vs 19)" || g.Outcome.Net != -10 {
+		t.Errorf("dealer drew to %v: %q, net %d", g.Dealer, g.Result, g.Outcome.Net)
+	}
+}
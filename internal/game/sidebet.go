@@ -0,0 +1,95 @@
+package game
+
+import "slices"
+
+// SideBetOutcome is the settlement of a side bet. A losing bet has no
+// Category and a multiplier of zero.
+type SideBetOutcome struct {
+	Name     string
+	Category string
+	// Multiplier is the payout to one, e.g. 25 for 25:1.
+	Multiplier int
+	Bet        int
+	// Net is the bet's change to the bankroll: its winnings, or minus the
+	// stake lost.
+	Net int
+}
+
+// PerfectPairs evaluates the Perfect Pairs side bet on the player's first two
+// cards: a same-suit "Perfect pair" pays 25:1, a same-color "Colored pair"
+// 12:1 and any other "Mixed pair" 6:1. Cards that don't pair return "" and 0.
+func PerfectPairs(c1, c2 Card) (category string, multiplier int) {
+	switch {
+		case c1.Rank != c2.Rank:
+			return "", 0
+		case c1.Suit == c2.Suit:
+			return "Perfect pair", 25
+		case c1.Color() == c2.Color():
+			return "Colored pair", 12
+		default:
+			return "Mixed pair", 6
+	}
+}
+
+// Evaluate213 evaluates the 21+3 side bet on the player's first two cards and
+// the dealer's upcard as a three-card poker hand: "Suited trips" pay 100:1, a
+// "Straight flush" 40:1, "Three of a kind" 30:1, a "Straight" 10:1 and a
+// "Flush" 5:1. Aces play high or low in straights, so A-2-3 and Q-K-A count
+// but K-A-2 does not. Anything else returns "" and 0.
+func Evaluate213(c1, c2, up Card) (category string, multiplier int) {
+	ranks := []int{int(c1.Rank), int(c2.Rank), int(up.Rank)}
+	slices.Sort(ranks)
+	trips := ranks[0] == ranks[2]
+	flush := c1.Suit == c2.Suit && c2.Suit == up.Suit
+	straight := (ranks[1] == ranks[0]+1 && ranks[2] == ranks[1]+1) ||
+		(ranks[0] == int(Ace) && ranks[1] == int(Queen) && ranks[2] == int(King))
+	switch {
+		case trips && flush:
+			return "Suited trips", 100
+		case straight && flush:
+			return "Straight flush", 40
+		case trips:
+			return "Three of a kind", 30
+		case straight:
+			return "Straight", 10
+		case flush:
+			return "Flush", 5
+		default:
+			return "", 0
+	}
+}
+
+// checkSideBets reports whether the side bets can be staked on the next deal.
+func (g *Game) checkSideBets() error {
+	if g.SidePerfectPairs < 0 || g.Side213 < 0 {
+		return ErrInvalidBet
+	}
+	if g.SidePerfectPairs+g.Side213 > g.Bankroll {
+		return ErrInsufficientFunds
+	}
+	return nil
+}
+
+// settleSideBets takes and pays the side bets on the first seat's opening
+// cards and the dealer's upcard once the deal is out.
+func (g *Game) settleSideBets() {
+	cards := g.PlayerHands[0].Cards
+	if g.SidePerfectPairs > 0 {
+		category, multiplier := PerfectPairs(cards[0], cards[1])
+		g.settleSideBet("Perfect Pairs", g.SidePerfectPairs, category, multiplier)
+	}
+	if g.Side213 > 0 {
+		category, multiplier := Evaluate213(cards[0], cards[1], g.Dealer.Cards[0])
+		g.settleSideBet("21+3", g.Side213, category, multiplier)
+	}
+}
+
+func (g *Game) settleSideBet(name string, bet int, category string, multiplier int) {
+	side := SideBetOutcome{Name: name, Category: category, Multiplier: multiplier, Bet: bet, Net: -bet}
+	g.Bankroll -= bet
+	if multiplier > 0 {
+		g.Bankroll += bet + bet*multiplier
+		side.Net = bet * multiplier
+	}
+	g.sideBets = append(g.sideBets, side)
+}
@@ -0,0 +1,54 @@
+package game
+
+import "testing"
+
+func TestPerfectPairs(t *testing.T) {
+	tests := []struct {
+		c1, c2     Card
+		category   string
+		multiplier int
+	}{
+		{Card{Hearts, Eight}, Card{Hearts, Eight}, "Perfect pair", 25},
+		{Card{Hearts, Eight}, Card{Diamonds, Eight}, "Colored pair", 12},
+		{Card{Hearts, Eight}, Card{Spades, Eight}, "Mixed pair", 6},
+		{Card{Hearts, King}, Card{Hearts, Queen}, "", 0},
+	}
+	for _, tt := range tests {
+		category, multiplier := PerfectPairs(tt.c1, tt.c2)
+		if category != tt.category || multiplier != tt.multiplier {
+			t.Errorf("PerfectPairs(%v, %v) = %q, %d, want %q, %d", tt.c1, tt.c2, category, multiplier, tt.category, tt.multiplier)
+		}
+	}
+}
+
+func TestPerfectPairsSettlesOnDeal(t *testing.T) {
+	g := stackedGame(DefaultRules(), Eight, Nine, Eight, Ten)
+	g.SidePerfectPairs = 5
+	g.PlaceBet(10)
+	g.Deal()
+	if g.Bankroll != DefaultBankroll-10+25*5 {
+		t.Errorf("bankroll after a perfect pair = %d, want %d", g.Bankroll, DefaultBankroll-10+25*5)
+	}
+}
+
+func TestEvaluate213(t *testing.T) {
+	tests := []struct {
+		c1, c2, up Card
+		category   string
+		multiplier int
+	}{
+		{Card{Hearts, Seven}, Card{Hearts, Seven}, Card{Hearts, Seven}, "Suited trips", 100},
+		{Card{Clubs, Four}, Card{Clubs, Five}, Card{Clubs, Six}, "Straight flush", 40},
+		{Card{Clubs, Seven}, Card{Hearts, Seven}, Card{Spades, Seven}, "Three of a kind", 30},
+		{Card{Clubs, Queen}, Card{Hearts, King}, Card{Spades, Ace}, "Straight", 10},
+		{Card{Clubs, Ace}, Card{Hearts, Two}, Card{Spades, Three}, "Straight", 10},
+		{Card{Diamonds, Two}, Card{Diamonds, Nine}, Card{Diamonds, King}, "Flush", 5},
+		{Card{Clubs, King}, Card{Hearts, Ace}, Card{Spades, Two}, "", 0},
+	}
+	for _, tt := range tests {
+		category, multiplier := Evaluate213(tt.c1, tt.c2, tt.up)
+		if category != tt.category || multiplier != tt.multiplier {
+			t.Errorf("Evaluate213(%v, %v, %v) = %q, %d, want %q, %d", tt.c1, tt.c2, tt.up, category, multiplier, tt.category, tt.multiplier)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// simBet is the stake per hand in simulations. It is large enough that half
+// and 3:2 payouts come out in whole chips; results are reported in units of
+// one bet.
+const simBet = 100
+
+// SimResult totals a simulation run. Net is the player's winnings in units
+// of the initial bet, negative when the house came out ahead, and Wagered is
+// the total staked in the same units, doubles and splits included.
+type SimResult struct {
+	Stats
+	Rounds  int
+	Net     float64
+	Wagered float64
+}
+
+// SimulateHands plays n rounds headlessly under rules, choosing every
+// decision with strategy, and returns the totals. The same seed always
+// plays out the same shoe and results. Insurance is taken when strategy
+// insures, and its stake counts as wagered; a decision the rules don't allow
+// in the situation is replaced by the basic-strategy play without it. Every
+// round is a flat bet whatever the table limits, since results are in units
+// of it. Rules the game rejects, such as a zero payout, stop the run before
+// any round.
+func SimulateHands(rules Rules, strategy Strategy, seed int64, n int) SimResult {
+	rules.MinBet, rules.MaxBet = 0, 0
+	g := NewGameWithRules(rules, seed)
+	g.headless = true
+	start := n * simBet * 4
+	g.Bankroll = start
+
+	rounds, wagered := 0, 0
+	for ; rounds < n; rounds++ {
+		if g.PlaceBet(simBet) != nil {
+			break
+		}
+		g.Deal()
+		g.PlayPlayerWith(strategy)
+		for _, hand := range g.PlayerHands {
+			wagered += hand.Bet
+		}
+		wagered += g.insuranceBet
+	}
+	return SimResult{
+		Stats:   g.Stats(),
+		Rounds:  rounds,
+		Net:     float64(g.Bankroll-start) / simBet,
+		Wagered: float64(wagered) / simBet,
+	}
+}
+
+// houseEdgeSeed fixes the shoe HouseEdge plays, so its figure is repeatable.
+const houseEdgeSeed = 1
+
+// HouseEdge simulates rounds under rules played by strategy and returns the
+// player's loss per unit wagered, e.g. 0.005 for a 0.5% house edge. The
+// figure is negative if the player came out ahead.
+func HouseEdge(rules Rules, strategy Strategy, rounds int) float64 {
+	res := SimulateHands(rules, strategy, houseEdgeSeed, rounds)
+	if res.Wagered == 0 {
+		return 0
+	}
+	return -res.Net / res.Wagered
+}
+
+// actionEVTrials caps ActionEV's work: each action is played out against
+// this many shuffles of the unseen cards.
+const actionEVTrials = 2000
+
+// actionEVSeed fixes the shuffles ActionEV plays out, so the same position
+// always gets the same estimates.
+const actionEVSeed = 1
+
+// ActionEV estimates, for each play AvailableActions offers the active hand,
+// the expected winnings in units of the hand's bet, e.g. -0.5 for a
+// surrender. Rather than assume a fresh shoe, it plays every action out
+// against actionEVTrials shuffles of the cards the player can't see: the
+// rest of the shoe and the hole card, which is never a natural once the
+// dealer has peeked. Later decisions on the hand and any hands split from it
+// follow BasicStrategy, and hands yet to play at other seats are left out.
+// Every action meets the same shuffles, so close calls are compared fairly.
+// It returns nil outside the player's turn, and leaves the game untouched.
+func (g *Game) ActionEV() map[Action]float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	hand := g.activeHand()
+	if g.State != PlayerTurn || hand == nil {
+		return nil
+	}
+	unseen := slices.Clone(g.Deck.cards)
+	hole := len(g.Dealer.Cards) == 2
+	if hole {
+		unseen = append(unseen, g.Dealer.Cards[1])
+	}
+	up := g.Dealer.Cards[0].Rank
+	peeked := hole && g.Rules.DealerPeek && !g.insuranceOpen && (up == Ace || up >= Ten)
+
+	evs := make(map[Action]float64)
+	cards := make([]Card, len(unseen))
+	for _, action := range g.availableActions() {
+		rng := rand.New(rand.NewSource(actionEVSeed))
+		net := 0
+		for range actionEVTrials {
+			copy(cards, unseen)
+			FisherYates(cards, rng)
+			shoe := cards
+			var holeCard Card
+			if hole {
+				shoe, holeCard = dealHole(cards, g.Dealer.Cards[0], peeked)
+			}
+			net += g.evTrial(action, shoe, holeCard, rng)
+		}
+		evs[action] = float64(net) / float64(actionEVTrials*hand.Bet)
+	}
+	return evs
+}
+
+// dealHole takes the dealer's hole card from the top of shuffled cards, the
+// first that doesn't make a natural with up if the dealer has peeked, and
+// returns the cards left with it.
+func dealHole(cards []Card, up Card, peeked bool) ([]Card, Card) {
+	i := len(cards) - 1
+	for peeked && i > 0 && (Hand{Cards: []Card{up, cards[i]}}).IsBlackjack() {
+		i--
+	}
+	last := len(cards) - 1
+	cards[i], cards[last] = cards[last], cards[i]
+	return cards[:last], cards[last]
+}
+
+// evTrial plays action on a copy of the round dealing from shoe, with the
+// dealer's hole card if there is one, finishes the round by basic strategy
+// and returns the net won on the active hand and any hands split from it.
+func (g *Game) evTrial(action Action, shoe []Card, hole Card, rng *rand.Rand) int {
+	sim := Game{
+		Deck: &Deck{
+			cards:       shoe,
+			rng:         rng,
+			shoe:        g.Deck.shoe,
+			size:        g.Deck.size,
+			BurnCards:   g.Deck.BurnCards,
+			ShuffleFunc: g.Deck.ShuffleFunc,
+		},
+		PlayerHands:   make([]Hand, g.Active+1),
+		Active:        g.Active,
+		Dealer:        g.Dealer,
+		State:         g.State,
+		Insured:       g.Insured,
+		Bankroll:      g.Bankroll,
+		Bet:           g.Bet,
+		Bets:          g.Bets,
+		Rules:         g.Rules,
+		DealerPolicy:  g.DealerPolicy,
+		insuranceOpen: g.insuranceOpen,
+		insuranceBet:  g.insuranceBet,
+		betPlaced:     g.betPlaced,
+		headless:      true,
+	}
+	for i, h := range g.PlayerHands[:g.Active+1] {
+		h.Cards = slices.Clone(h.Cards)
+		sim.PlayerHands[i] = h
+	}
+	sim.Dealer.Cards = slices.Clone(g.Dealer.Cards)
+	if len(sim.Dealer.Cards) == 2 {
+		sim.Dealer.Cards[1] = hole
+	}
+
+	sim.playAction(action)
+	sim.PlayPlayerWith(BasicStrategyPlayer{})
+	net := 0
+	for _, h := range sim.Outcome.Hands[g.Active:] {
+		net += h.Net
+	}
+	return net
+}
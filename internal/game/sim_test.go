@@ -0,0 +1,133 @@
+package game
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestSimulateHandsIgnoresTableLimits(t *testing.T) {
+	rules := DefaultRules()
+	rules.MinBet, rules.MaxBet = 200, 250
+	if res := SimulateHands(rules, BasicStrategyPlayer{}, 1, 100); res.Rounds != 100 {
+		t.Errorf("SimulateHands played %d rounds, want 100", res.Rounds)
+	}
+}
+
+func BenchmarkSimulateHands(b *testing.B) {
+	rules := DefaultRules()
+	b.ReportAllocs()
+	SimulateHands(rules, BasicStrategyPlayer{}, 1, b.N)
+}
+
+func TestSimulatedRoundsDontAllocate(t *testing.T) {
+	rules := DefaultRules()
+	g := NewGameWithRules(rules, 1)
+	g.headless = true
+	g.Bankroll = 1 << 30
+	rounds := func() {
+		for i := 0; i < 100; i++ {
+			g.PlaceBet(simBet)
+			g.Deal()
+			g.PlayPlayerWith(BasicStrategyPlayer{})
+		}
+	}
+	rounds()
+	// Only splits, dealing a new hand, may allocate.
+	if allocs := testing.AllocsPerRun(20, rounds); allocs > 10 {
+		t.Errorf("100 headless rounds made %v allocations", allocs)
+	}
+}
+
+func TestSixToFivePayoutRaisesHouseEdge(t *testing.T) {
+	threeToTwo := DefaultRules()
+	sixToFive := DefaultRules()
+	sixToFive.BlackjackPayout = 1.2
+	edge32 := HouseEdge(threeToTwo, BasicStrategyPlayer{}, 50000)
+	edge65 := HouseEdge(sixToFive, BasicStrategyPlayer{}, 50000)
+	// Naturals come about one round in 21, so 6:5 costs about 1.4%.
+	if diff := edge65 - edge32; diff < 0.01 || diff > 0.02 {
+		t.Errorf("6:5 edge %.4f vs 3:2 %.4f: difference %.4f, want about 0.014", edge65, edge32, diff)
+	}
+}
+
+func TestPlaceBetRejectsNonPositivePayout(t *testing.T) {
+	for _, payout := range []float64{0, -1.5} {
+		g := NewGame(1)
+		g.Rules.BlackjackPayout = payout
+		if err := g.PlaceBet(10); err != ErrInvalidPayout {
+			t.Errorf("payout %v: PlaceBet = %v, want %v", payout, err, ErrInvalidPayout)
+		}
+	}
+}
+
+func TestHouseEdgeBasicStrategy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("simulates millions of rounds")
+	}
+	// Six decks, dealer stands on soft 17, double after split, late
+	// surrender, no resplit aces: published figures put the edge at about
+	// 0.35%. Two million rounds pin it to within about 0.08%, so the window
+	// is a few of those either side.
+	s17 := DefaultRules()
+	s17.DealerHitsSoft17 = false
+	edge := HouseEdge(s17, BasicStrategyPlayer{}, 2000000)
+	if edge < 0.001 || edge > 0.006 {
+		t.Errorf("S17 house edge = %.3f%%, want about 0.35%%", 100*edge)
+	}
+	// Hitting soft 17 costs the player about 0.2%.
+	if h17 := HouseEdge(DefaultRules(), BasicStrategyPlayer{}, 2000000); h17-edge < 0.001 || h17-edge > 0.004 {
+		t.Errorf("H17 house edge %.3f%% vs S17 %.3f%%, want about 0.2%% more", 100*h17, 100*edge)
+	}
+}
+
+func TestAlwaysInsuringRaisesHouseEdge(t *testing.T) {
+	rules := DefaultRules()
+	never := HouseEdge(rules, BasicStrategyPlayer{}, 200000)
+	always := HouseEdge(rules, BasicStrategyPlayer{AlwaysInsure: true}, 200000)
+	// Insurance alone gives the house about 7% in a six-deck shoe, but it is
+	// only offered one round in 13 for half the bet.
+	if always-never < 0.001 {
+		t.Errorf("always insuring: edge %.4f, never insuring %.4f; want insurance to cost the player", always, never)
+	}
+}
+
+func TestActionEV(t *testing.T) {
+	tests := []struct {
+		name  string
+		ranks []Rank
+		want  map[Action]float64
+	}{
+		// Every unseen card is a five, so a hit makes 21 and the dealer's
+		// 15 draws to 20, though basic strategy surrenders 16 against a ten.
+		{"fives left", []Rank{Ten, Ten, Six, Five, Five, Five, Five, Five, Five, Five, Five, Five},
+			map[Action]float64{Hit: 1, Stand: -1, Double: 2, Surrender: -0.5}},
+		// The dealer peeked, so the hole card is the 7 and none of the aces.
+		{"aces left after the peek", []Rank{Ten, Ten, Six, Seven, Ace, Ace, Ace, Ace, Ace, Ace, Ace, Ace},
+			map[Action]float64{Hit: 0, Stand: -1, Double: 0, Surrender: -0.5}},
+	}
+	for _, tt := range tests {
+		g := stackedGame(DefaultRules(), tt.ranks...)
+		g.PlaceBet(10)
+		g.Deal()
+		left, dealer := g.Deck.UpcomingOrder(), slices.Clone(g.Dealer.Cards)
+		if got := g.ActionEV(); !maps.Equal(got, tt.want) {
+			t.Errorf("%s: ActionEV() = %v, want %v", tt.name, got, tt.want)
+		}
+		if !slices.Equal(g.Deck.UpcomingOrder(), left) || !slices.Equal(g.Dealer.Cards, dealer) || len(g.PlayerHands[0].Cards) != 2 {
+			t.Errorf("%s: ActionEV changed the game", tt.name)
+		}
+	}
+
+	g := stackedGame(DefaultRules(), Ten, Ten, Six, Five, Five, Five, Five, Five)
+	g.PlaceBet(10)
+	g.Deal()
+	up, _ := g.DealerUpcard()
+	if BasicStrategy(g.PlayerHands[0], up, g.Rules) == Double {
+		t.Fatal("basic strategy already doubles 16 against a ten")
+	}
+	g.PlayerStand()
+	if got := g.ActionEV(); got != nil {
+		t.Errorf("ActionEV() after the round = %v, want none", got)
+	}
+}
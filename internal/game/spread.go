@@ -0,0 +1,42 @@
+package game
+
+import "math"
+
+// BetSpread is a counter's betting ramp: the table minimum up to a true count
+// of Floor, then UnitsPerCount more minimum bets for each count above it, up
+// to MaxUnits minimum bets.
+type BetSpread struct {
+	Floor         float64
+	UnitsPerCount float64
+	MaxUnits      float64
+}
+
+// DefaultBetSpread is a 1-12 spread that starts ramping above a true count of
+// 1, two units a count.
+var DefaultBetSpread = BetSpread{Floor: 1, UnitsPerCount: 2, MaxUnits: 12}
+
+// SuggestBet returns the bet DefaultBetSpread calls for at trueCount; see
+// BetSpread.Suggest.
+func SuggestBet(trueCount float64, rules Rules, bankroll int) int {
+	return DefaultBetSpread.Suggest(trueCount, rules, bankroll)
+}
+
+// Suggest returns the bet the spread calls for at trueCount, in whole
+// multiples of the table minimum (1 with no minimum), kept within the table
+// maximum and the bankroll. It is 0 if the bankroll can't cover the minimum.
+func (s BetSpread) Suggest(trueCount float64, rules Rules, bankroll int) int {
+	unit := max(rules.MinBet, 1)
+	if bankroll < unit {
+		return 0
+	}
+	units := 1.0
+	if trueCount > s.Floor {
+		units += math.Floor((trueCount - s.Floor) * s.UnitsPerCount)
+	}
+	units = min(units, max(s.MaxUnits, 1))
+	bet := unit * int(units)
+	if rules.MaxBet > 0 {
+		bet = min(bet, rules.MaxBet)
+	}
+	return min(bet, bankroll/unit*unit)
+}
@@ -0,0 +1,39 @@
+package game
+
+import "testing"
+
+func TestSuggestBet(t *testing.T) {
+	rules := DefaultRules()
+	tests := []struct {
+		name      string
+		trueCount float64
+		bankroll  int
+		want      int
+	}{
+		{"negative count", -3, 1000, 5},
+		{"neutral count", 0, 1000, 5},
+		{"at the floor", 1, 1000, 5},
+		{"count of 2", 2, 1000, 15},
+		{"count of 3.7", 3.7, 1000, 30},
+		{"high count", 10, 1000, 60},
+		{"short bankroll", 10, 22, 20},
+		{"broke", 10, 4, 0},
+	}
+	for _, tt := range tests {
+		if got := SuggestBet(tt.trueCount, rules, tt.bankroll); got != tt.want {
+			t.Errorf("%s: SuggestBet(%v, bankroll %d) = %d, want %d", tt.name, tt.trueCount, tt.bankroll, got, tt.want)
+		}
+	}
+}
+
+func TestBetSpreadClampsAtTableMax(t *testing.T) {
+	rules := DefaultRules()
+	rules.MinBet, rules.MaxBet = 25, 200
+	spread := BetSpread{Floor: 1, UnitsPerCount: 4, MaxUnits: 20}
+	if got := spread.Suggest(6, rules, 10000); got != 200 {
+		t.Errorf("Suggest at a count of 6 = %d, want the 200 table max", got)
+	}
+	if got := spread.Suggest(1, rules, 10000); got != 25 {
+		t.Errorf("Suggest at a count of 1 = %d, want the 25 minimum", got)
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+// Stats are running totals for a session. Each resolved hand counts once, so
+// a split round adds one hand per split hand.
+type Stats struct {
+	Wins        int
+	Losses      int
+	Pushes      int
+	Blackjacks  int
+	HandsPlayed int
+}
+
+// Stats returns a copy of the session statistics.
+func (g *Game) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stats
+}
+
+func (g *Game) ResetStats() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stats = Stats{}
+}
+
+func (g *Game) recordHand(hand Hand, outcome int) {
+	g.stats.HandsPlayed++
+	if hand.IsBlackjack() {
+		g.stats.Blackjacks++
+	}
+	switch {
+		case outcome > 0:
+			g.stats.Wins++
+		case outcome < 0:
+			g.stats.Losses++
+		default:
+			g.stats.Pushes++
+	}
+}
@@ -0,0 +1,257 @@
+package game
+
+// Action is a player decision.
+type Action int
+
+const (
+	Hit Action = iota
+	Stand
+	Double
+	Split
+	Surrender
+	// DealNext starts the next round. It is only open once a round is over,
+	// and no Strategy plays it.
+	DealNext
+)
+
+func (a Action) String() string {
+	return map[Action]string{
+		Hit:       "Hit",
+		Stand:     "Stand",
+		Double:    "Double",
+		Split:     "Split",
+		Surrender: "Surrender",
+		DealNext:  "Deal next",
+	}[a]
+}
+
+// Strategy decides the player's plays, e.g. to drive the game with a bot.
+// Insure is only asked when insurance is offered, and reports whether to
+// take it.
+type Strategy interface {
+	Decide(hand Hand, dealerUpcard Card, rules Rules) Action
+	Insure(hand Hand, dealerUpcard Card, rules Rules) bool
+}
+
+// BasicStrategyPlayer is a Strategy that plays BasicStrategy. Basic strategy
+// never insures; AlwaysInsure takes insurance whenever it is offered.
+type BasicStrategyPlayer struct {
+	AlwaysInsure bool
+}
+
+func (BasicStrategyPlayer) Decide(hand Hand, dealerUpcard Card, rules Rules) Action {
+	return BasicStrategy(hand, dealerUpcard, rules)
+}
+
+func (p BasicStrategyPlayer) Insure(Hand, Card, Rules) bool {
+	return p.AlwaysInsure
+}
+
+// PlayPlayerWith plays out the player's turn, every hand in order, with the
+// decisions of s. A decision the game refuses in the situation is replaced
+// by the basic-strategy play without it, e.g. the play for a hard 18 on a
+// pair of nines that may not split. It returns once the round has passed to
+// the dealer or ended.
+func (g *Game) PlayPlayerWith(s Strategy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.offerInsurance() {
+		up, _ := g.dealerUpcard()
+		if s.Insure(*g.activeHand(), up, g.Rules) {
+			g.takeInsurance()
+		}
+		g.declineInsurance()
+	}
+	for g.State == PlayerTurn {
+		up, _ := g.dealerUpcard()
+		g.playAction(s.Decide(*g.activeHand(), up, g.Rules))
+	}
+}
+
+// playAction plays action on the active hand. If the game refuses it, the
+// basic-strategy play with action ruled out is played instead, e.g. a stand
+// on a soft 18 that may not double, and a hit if that is refused too.
+func (g *Game) playAction(action Action) {
+	if g.tryAction(action) || g.tryAction(g.fallbackAction(action)) {
+		return
+	}
+	g.playerHit()
+}
+
+// tryAction plays action on the active hand and reports whether the game
+// took it.
+func (g *Game) tryAction(action Action) bool {
+	active, hands := g.Active, len(g.PlayerHands)
+	cards := len(g.activeHand().Cards)
+	switch action {
+		case Hit:
+			g.playerHit()
+		case Stand:
+			g.playerStand()
+		case Double:
+			g.playerDoubleDown()
+		case Split:
+			g.playerSplit()
+		case Surrender:
+			g.playerSurrender()
+	}
+	return g.State != PlayerTurn || g.Active != active || len(g.PlayerHands) != hands || len(g.activeHand().Cards) != cards
+}
+
+// fallbackAction decides the active hand again by basic strategy, with
+// refused and any other play the game won't take ruled out.
+func (g *Game) fallbackAction(refused Action) Action {
+	up, _ := g.dealerUpcard()
+	canSplit := g.canSplit() && refused != Split
+	canDouble := g.canDoubleDown() && refused != Double
+	canSurrender := g.canSurrender() && refused != Surrender
+	return basicStrategy(*g.activeHand(), up.BlackjackValue(), g.Rules, canSplit, canDouble, canSurrender)
+}
+
+// BasicStrategy returns the basic-strategy play for player against the
+// dealer's upcard, from the standard multi-deck tables. Split, Double and
+// Surrender are only returned where the rules allow them: a split under
+// MaxSplits, and a split ace again only under ResplitAces; a double or
+// surrender on a two-card hand and, on a hand made by a split, only doubling
+// under DoubleAfterSplit. Otherwise the table's alternative play is
+// returned. The splits a seat has already made aren't known from the hand,
+// so a Split may still be refused once MaxSplits is used up.
+func BasicStrategy(player Hand, dealerUpcard Card, opts Rules) Action {
+	twoCards := len(player.Cards) == 2
+	canSplit := player.CanSplit() && opts.MaxSplits > 0 && (!player.FromSplit || player.Cards[0].Rank != Ace || opts.ResplitAces)
+	canDouble := twoCards && (!player.FromSplit || opts.DoubleAfterSplit)
+	canSurrender := twoCards && !player.FromSplit && opts.Surrender
+	return basicStrategy(player, dealerUpcard.BlackjackValue(), opts, canSplit, canDouble, canSurrender)
+}
+
+// basicStrategy is BasicStrategy against an upcard worth up, with the plays
+// on offer decided by the caller.
+func basicStrategy(player Hand, up int, opts Rules, canSplit, canDouble, canSurrender bool) Action {
+	hard, soft, isSoft := player.Totals()
+	if canSplit && player.Cards[0].Rank != Five {
+		if splitPair(player.Cards[0].Rank, up, opts) {
+			return Split
+		}
+	}
+
+	if isSoft {
+		return softStrategy(soft, up, canDouble, opts)
+	}
+	return hardStrategy(hard, up, canDouble, canSurrender, opts)
+}
+
+func splitPair(r Rank, up int, opts Rules) bool {
+	das := opts.DoubleAfterSplit
+	switch {
+		case r == Ace, r == Eight:
+			return true
+		case r >= Ten:
+			return false
+		case r == Nine:
+			return up <= 9 && up != 7
+		case r == Seven:
+			return up <= 7
+		case r == Six:
+			return up <= 6 && (das || up >= 3)
+		case r == Four:
+			return das && (up == 5 || up == 6)
+		default:
+			// Twos and threes.
+			return up <= 7 && (das || up >= 4)
+	}
+}
+
+func hardStrategy(total, up int, canDouble, canSurrender bool, opts Rules) Action {
+	switch {
+		case total >= 17:
+			if total == 17 && up == 11 && opts.DealerHitsSoft17 && canSurrender {
+				return Surrender
+			}
+			return Stand
+		case total == 16:
+			if up >= 9 && canSurrender {
+				return Surrender
+			}
+			if up <= 6 {
+				return Stand
+			}
+			return Hit
+		case total == 15:
+			if canSurrender && (up == 10 || (up == 11 && opts.DealerHitsSoft17)) {
+				return Surrender
+			}
+			if up <= 6 {
+				return Stand
+			}
+			return Hit
+		case total >= 13:
+			if up <= 6 {
+				return Stand
+			}
+			return Hit
+		case total == 12:
+			if up >= 4 && up <= 6 {
+				return Stand
+			}
+			return Hit
+		case total == 11:
+			if up == 11 && !opts.DealerHitsSoft17 {
+				return Hit
+			}
+			return doubleOr(Hit, canDouble)
+		case total == 10:
+			if up <= 9 {
+				return doubleOr(Hit, canDouble)
+			}
+			return Hit
+		case total == 9:
+			if up >= 3 && up <= 6 {
+				return doubleOr(Hit, canDouble)
+			}
+			return Hit
+		default:
+			return Hit
+	}
+}
+
+func softStrategy(total, up int, canDouble bool, opts Rules) Action {
+	switch {
+		case total >= 20:
+			return Stand
+		case total == 19:
+			if up == 6 && opts.DealerHitsSoft17 {
+				return doubleOr(Stand, canDouble)
+			}
+			return Stand
+		case total == 18:
+			if up <= 6 && (up >= 3 || opts.DealerHitsSoft17) {
+				return doubleOr(Stand, canDouble)
+			}
+			if up <= 8 {
+				return Stand
+			}
+			return Hit
+		case total == 17:
+			if up >= 3 && up <= 6 {
+				return doubleOr(Hit, canDouble)
+			}
+			return Hit
+		case total >= 15:
+			if up >= 4 && up <= 6 {
+				return doubleOr(Hit, canDouble)
+			}
+			return Hit
+		default:
+			if up == 5 || up == 6 {
+				return doubleOr(Hit, canDouble)
+			}
+			return Hit
+	}
+}
+
+func doubleOr(fallback Action, canDouble bool) Action {
+	if canDouble {
+		return Double
+	}
+	return fallback
+}
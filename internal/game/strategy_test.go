@@ -0,0 +1,132 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBasicStrategyCells(t *testing.T) {
+	noSurrender := DefaultRules()
+	noSurrender.Surrender = false
+	tests := []struct {
+		name   string
+		player []Rank
+		up     Rank
+		rules  Rules
+		want   Action
+	}{
+		{"16 v 10", []Rank{Ten, Six}, King, noSurrender, Hit},
+		{"16 v 10, surrender", []Rank{Ten, Six}, King, DefaultRules(), Surrender},
+		{"A,7 v 9", []Rank{Ace, Seven}, Nine, DefaultRules(), Hit},
+		{"11 v 6", []Rank{Six, Five}, Six, DefaultRules(), Double},
+		{"12 v 4", []Rank{Ten, Two}, Four, DefaultRules(), Stand},
+		{"T,T v 6", []Rank{Ten, King}, Six, DefaultRules(), Stand},
+	}
+	for _, tt := range tests {
+		got := BasicStrategy(Hand{Cards: spades(tt.player...)}, Card{Rank: tt.up}, tt.rules)
+		if got != tt.want {
+			t.Errorf("%s: BasicStrategy = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	noSplits := DefaultRules()
+	noSplits.MaxSplits = 0
+	if got := BasicStrategy(Hand{Cards: spades(Nine, Nine)}, Card{Rank: Six}, noSplits); got != Stand {
+		t.Errorf("9,9 v 6 without splits: BasicStrategy = %v, want Stand", got)
+	}
+	for up := Ace; up <= King; up++ {
+		if got := BasicStrategy(Hand{Cards: spades(Eight, Eight)}, Card{Rank: up}, DefaultRules()); got != Split {
+			t.Errorf("8,8 v %v: BasicStrategy = %v, want Split", Card{Rank: up}, got)
+		}
+	}
+}
+
+func TestBasicStrategySplitHands(t *testing.T) {
+	noDAS := DefaultRules()
+	noDAS.DoubleAfterSplit = false
+	tests := []struct {
+		name   string
+		player []Rank
+		up     Rank
+		rules  Rules
+		want   Action
+	}{
+		{"split 17 v A", []Rank{Eight, Nine}, Ace, DefaultRules(), Stand},
+		{"split 16 v 10", []Rank{Eight, Eight}, Ten, DefaultRules(), Split},
+		{"split 15 v 10", []Rank{Eight, Seven}, Ten, DefaultRules(), Hit},
+		{"split A,7 v 5, no DAS", []Rank{Ace, Seven}, Five, noDAS, Stand},
+		{"split 11 v 6, no DAS", []Rank{Eight, Three}, Six, noDAS, Hit},
+		{"split 11 v 6, DAS", []Rank{Eight, Three}, Six, DefaultRules(), Double},
+		{"split A,A v 6, no resplit", []Rank{Ace, Ace}, Six, DefaultRules(), Double},
+	}
+	for _, tt := range tests {
+		hand := Hand{Cards: spades(tt.player...), FromSplit: true}
+		if got := BasicStrategy(hand, Card{Rank: tt.up}, tt.rules); got != tt.want {
+			t.Errorf("%s: BasicStrategy = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlayPlayerWithBasicStrategy(t *testing.T) {
+	// 8,8 against the dealer's A,6: split, both hands draw a 9 to a hard 17
+	// and stand. The dealer hits soft 17 with a 5, and a 10 to bust.
+	g := stackedGame(DefaultRules(), Eight, Ace, Eight, Six, Nine, Nine, Five, Ten)
+	g.PlaceBet(10)
+	g.Deal()
+	g.PlayPlayerWith(BasicStrategyPlayer{})
+	if g.State != RoundOver {
+		t.Fatalf("round not over: %v", g.State)
+	}
+	actions := g.History()[0].Actions
+	want := []Action{Split, Stand, Stand}
+	if len(actions) != len(want) {
+		t.Fatalf("actions = %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("actions = %v, want %v", actions, want)
+		}
+	}
+	if g.Bankroll != DefaultBankroll+20 {
+		t.Errorf("bankroll = %d, want both hands won: %d", g.Bankroll, DefaultBankroll+20)
+	}
+}
+
+// alwaysPlayer is a Strategy that always decides the same play.
+type alwaysPlayer Action
+
+func (p alwaysPlayer) Decide(Hand, Card, Rules) Action { return Action(p) }
+func (alwaysPlayer) Insure(Hand, Card, Rules) bool     { return false }
+
+func TestPlayPlayerWithRefusedPlay(t *testing.T) {
+	noSplits := DefaultRules()
+	noSplits.MaxSplits = 0
+	tests := []struct {
+		name   string
+		rules  Rules
+		ranks  []Rank
+		action Action
+		hit    bool
+		want   []Action
+	}{
+		// 9,9 that may not split is a hard 18 against the dealer's 6,10,
+		// who draws a 5.
+		{"refused split", noSplits, []Rank{Nine, Six, Nine, Ten, Five}, Split, false, []Action{Stand}},
+		// A,5 hit to a soft 18 may no longer double against the same 6,10.
+		{"refused soft double", DefaultRules(), []Rank{Ace, Six, Five, Ten, Two, Five}, Double, true, []Action{Hit, Stand}},
+	}
+	for _, tt := range tests {
+		g := stackedGame(tt.rules, tt.ranks...)
+		g.PlaceBet(10)
+		g.Deal()
+		if tt.hit {
+			g.PlayerHit()
+		}
+		g.PlayPlayerWith(alwaysPlayer(tt.action))
+		if g.State != RoundOver {
+			t.Fatalf("%s: round not over: %v", tt.name, g.State)
+		}
+		if got := g.History()[0].Actions; !slices.Equal(got, tt.want) {
+			t.Errorf("%s: actions = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package game
+
+// Do runs f holding the game's lock, so f can use the exported fields and
+// the Deck while other goroutines play. f must not call the game's methods,
+// which take the lock themselves.
+func (g *Game) Do(f func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f()
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// TestGameConcurrentUse is meant for go test -race: a bot plays rounds while
+// another goroutine reads the table.
+func TestGameConcurrentUse(t *testing.T) {
+	g := NewGameWithSeed(6, 1)
+	g.ManualDealer = true
+	g.Bankroll = 1 << 20
+	state := func() State {
+		var s State
+		g.Do(func() { s = g.State })
+		return s
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+				case <-stop:
+					return
+				default:
+			}
+			state()
+			g.Stats()
+			g.AvailableActions()
+			_ = g.VisibleDealer().String()
+			g.Do(func() { _ = g.Bankroll + g.Deck.RunningCount() })
+		}
+	}()
+
+	for i := 0; i < 500; i++ {
+		if i == 0 {
+			if err := g.PlaceBets(10); err != nil {
+				t.Fatal(err)
+			}
+			g.Deal()
+		} else if err := g.Rebet(); err != nil {
+			t.Fatal(err)
+		}
+		g.DeclineInsurance()
+		g.PlayerHit()
+		g.Undo()
+		g.PlayerStand()
+		if err := g.RunDealer(context.Background(), 0, nil); err != nil {
+			t.Fatal(err)
+		}
+		if s := state(); s != RoundOver {
+			t.Fatalf("round %d ended in %v", i, s)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	if played := g.Stats().HandsPlayed; played < 500 {
+		t.Errorf("%d hands played, want at least 500", played)
+	}
+}
@@ -0,0 +1,35 @@
+package game
+
+// undoStep records what a PlayerHit or PlayerStand changed so Undo can
+// revert it.
+type undoStep struct {
+	active       int
+	drew         bool
+	card         Card
+	needsShuffle bool
+}
+
+// Undo reverts the most recent PlayerHit or PlayerStand, putting any drawn
+// card back on top of the deck so the next draw returns it again. It reports
+// false when there is nothing to undo: once the round is over, or when the
+// last action was a double, split, surrender or insurance decision.
+func (g *Game) Undo() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.State != PlayerTurn || len(g.undo) == 0 {
+		return false
+	}
+	step := g.undo[len(g.undo)-1]
+	g.undo = g.undo[:len(g.undo)-1]
+	g.actions = g.actions[:len(g.actions)-1]
+	g.Active = step.active
+	if step.drew {
+		hand := g.activeHand()
+		hand.Cards = hand.Cards[:len(hand.Cards)-1]
+		g.Deck.cards = append(g.Deck.cards, step.card)
+		g.Deck.discard = g.Deck.discard[:len(g.Deck.discard)-1]
+		g.Deck.running -= hiLo(step.card)
+		g.Deck.needsShuffle = step.needsShuffle
+	}
+	return true
+}